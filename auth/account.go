@@ -1,10 +1,13 @@
 package auth
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/xuperchain/crypto/client/service/gm"
 	"github.com/xuperchain/crypto/client/service/xchain"
 	"github.com/xuperchain/crypto/core/hdwallet/wordlist"
+	"github.com/xuperchain/xasset-sdk-go/common/redact"
 )
 
 // 助记词强度：弱、中、强
@@ -31,6 +34,16 @@ const (
 	MnemLangEN
 )
 
+// 密码学类型
+type CryptoType int
+
+const (
+	// 0:NIST ECDSA(默认)
+	CryptoTypeNist CryptoType = iota
+	// 1:国密SM2/SM3
+	CryptoTypeGm
+)
+
 type Account struct {
 	// 钱包地址
 	Address string `json:"address,omitempy"`
@@ -40,6 +53,18 @@ type Account struct {
 	PublicKey string `json:"public_key,omitempy"`
 	// 助记词
 	Mnemonic string `json:"mnemonic,omitempy"`
+	// 密码学类型，为空时为NIST ECDSA
+	CryptoType CryptoType `json:"crypto_type,omitempty"`
+}
+
+// String 私钥和助记词脱敏，避免通过日志或%v输出泄露
+func (t Account) String() string {
+	return fmt.Sprintf("{Address:%s PublicKey:%s PrivateKey:%s Mnemonic:%s CryptoType:%s}",
+		t.Address, t.PublicKey, redact.Secret(t.PrivateKey), redact.Secret(t.Mnemonic), t.CryptoType)
+}
+
+func (t Account) GoString() string {
+	return t.String()
 }
 
 // 新创建xuperchain ecdsa账户
@@ -61,6 +86,37 @@ func NewXchainEcdsaAccount(strg MnemStrgth, lang MnemLang) (*Account, error) {
 	return acc, nil
 }
 
+// 新创建xuperchain国密账户
+func NewXchainGmAccount(strg MnemStrgth, lang MnemLang) (*Account, error) {
+	cryptoCli := &gm.GmCryptoClient{}
+
+	gmAcc, err := cryptoCli.CreateNewAccountWithMnemonic(int(lang), uint8(strg))
+	if err != nil {
+		return nil, err
+	}
+
+	acc := &Account{
+		Address:    gmAcc.Address,
+		PrivateKey: gmAcc.JsonPrivateKey,
+		PublicKey:  gmAcc.JsonPublicKey,
+		Mnemonic:   gmAcc.Mnemonic,
+		CryptoType: CryptoTypeGm,
+	}
+
+	return acc, nil
+}
+
+// 按密码学类型新创建账户
+func NewAccount(ct CryptoType, strg MnemStrgth, lang MnemLang) (*Account, error) {
+	switch ct {
+	case CryptoTypeNist:
+		return NewXchainEcdsaAccount(strg, lang)
+	case CryptoTypeGm:
+		return NewXchainGmAccount(strg, lang)
+	}
+	return nil, ErrCryptoType
+}
+
 // 根据助记词生成历史账户
 func RetrieveAccountByMnemonic(mnemonic string, language int) (*Account, error) {
 	cryptoCli := &xchain.XchainCryptoClient{}
@@ -80,11 +136,42 @@ func RetrieveAccountByMnemonic(mnemonic string, language int) (*Account, error)
 	return acc, nil
 }
 
+// 根据助记词生成历史国密账户
+func RetrieveGmAccountByMnemonic(mnemonic string, language int) (*Account, error) {
+	cryptoCli := &gm.GmCryptoClient{}
+
+	gmAcc, err := cryptoCli.RetrieveAccountByMnemonic(mnemonic, language)
+	if err != nil {
+		return nil, err
+	}
+
+	acc := &Account{
+		Address:    gmAcc.Address,
+		PrivateKey: gmAcc.JsonPrivateKey,
+		PublicKey:  gmAcc.JsonPublicKey,
+		Mnemonic:   gmAcc.Mnemonic,
+		CryptoType: CryptoTypeGm,
+	}
+
+	return acc, nil
+}
+
+// 按密码学类型根据助记词生成历史账户
+func RetrieveAccount(ct CryptoType, mnemonic string, language int) (*Account, error) {
+	switch ct {
+	case CryptoTypeNist:
+		return RetrieveAccountByMnemonic(mnemonic, language)
+	case CryptoTypeGm:
+		return RetrieveGmAccountByMnemonic(mnemonic, language)
+	}
+	return nil, ErrCryptoType
+}
+
 // InferLanguage 根据助记词推测语言
 //  如果推测失败，返回 0
 //  这里不做合法性检查，仅推测可能值
 func InferLanguage(mnemonic string) int {
-	ss := strings.Split(mnemonic, " ")
+	ss := strings.Fields(NormalizeMnemonic(mnemonic))
 	if len(ss) == 0 {
 		return 0
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/xuperchain/crypto/core/account"
 	"github.com/xuperchain/crypto/core/hash"
 	"github.com/xuperchain/crypto/core/sign"
+	gmaccount "github.com/xuperchain/crypto/gm/account"
 )
 
 // xasset签名完整方法
@@ -98,13 +99,19 @@ func VerifyECDSA(k *ecdsa.PublicKey, signature, msg []byte) (bool, error) {
 	return result, nil
 }
 
-// 从json格式私钥内容字符串产生ECC私钥
+// 从json格式私钥内容字符串产生ECC私钥，支持NIST和国密曲线
 func GetEcdsaPriKeyByJsStr(keyStr string) (*ecdsa.PrivateKey, error) {
+	if ct, _ := KeyCryptoType(keyStr); ct == CryptoTypeGm {
+		return gmaccount.GetEcdsaPrivateKeyFromJson([]byte(keyStr))
+	}
 	return account.GetEcdsaPrivateKeyFromJson([]byte(keyStr))
 }
 
-// 从json格式公钥内容字符串产生ECC公钥
+// 从json格式公钥内容字符串产生ECC公钥，支持NIST和国密曲线
 func GetEcdsaPubKeyByJsStr(keyStr string) (*ecdsa.PublicKey, error) {
+	if ct, _ := KeyCryptoType(keyStr); ct == CryptoTypeGm {
+		return gmaccount.GetEcdsaPublicKeyFromJson([]byte(keyStr))
+	}
 	return account.GetEcdsaPublicKeyFromJson([]byte(keyStr))
 }
 
@@ -113,6 +120,9 @@ func GetAddrByPubKey(key *ecdsa.PublicKey) (string, error) {
 	if key == nil {
 		return "", fmt.Errorf("public key unset")
 	}
+	if isGmKey(key) {
+		return gmaccount.GetAddressFromPublicKey(key)
+	}
 
 	return account.GetAddressFromPublicKey(key)
 }
@@ -122,6 +132,9 @@ func VerifyAddrByPubKey(address string, pub *ecdsa.PublicKey) (bool, uint8) {
 	if pub == nil {
 		return false, 0
 	}
+	if isGmKey(pub) {
+		return gmaccount.VerifyAddressUsingPublicKey(address, pub)
+	}
 
 	return account.VerifyAddressUsingPublicKey(address, pub)
 }
@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	gmaccount "github.com/xuperchain/crypto/gm/account"
+	gmconfig "github.com/xuperchain/crypto/gm/config"
+	gmhash "github.com/xuperchain/crypto/gm/hash"
+	gmsign "github.com/xuperchain/crypto/gm/sign"
+)
+
+var ErrCryptoType = errors.New("crypto type unsupported")
+
+func (t CryptoType) String() string {
+	switch t {
+	case CryptoTypeNist:
+		return "nist"
+	case CryptoTypeGm:
+		return "gm"
+	}
+	return fmt.Sprintf("unknown(%d)", int(t))
+}
+
+// ParseCryptoType 解析nist|gm
+func ParseCryptoType(s string) (CryptoType, error) {
+	switch s {
+	case "", "nist":
+		return CryptoTypeNist, nil
+	case "gm":
+		return CryptoTypeGm, nil
+	}
+	return 0, ErrCryptoType
+}
+
+// KeyCryptoType 根据json格式公钥或私钥中的曲线名称判断密码学类型
+func KeyCryptoType(jsKey string) (CryptoType, error) {
+	var k struct {
+		Curvname string
+	}
+	if err := json.Unmarshal([]byte(jsKey), &k); err != nil {
+		return 0, err
+	}
+	switch k.Curvname {
+	case gmconfig.CurveNist:
+		return CryptoTypeNist, nil
+	case gmconfig.CurveGm:
+		return CryptoTypeGm, nil
+	}
+	return 0, ErrCryptoType
+}
+
+func isGmKey(k *ecdsa.PublicKey) bool {
+	return k != nil && k.Curve != nil && k.Params().Name == gmconfig.CurveGm
+}
+
+// xasset国密签名完整方法，消息先做SM3
+// @jsPrivtKey: json格式的SM2 private key
+// @oriMsg: 签名的原始数据
+func XassetSignSM2(jsPrivtKey string, oriMsg []byte) (string, error) {
+	k, err := gmaccount.GetEcdsaPrivateKeyFromJson([]byte(jsPrivtKey))
+	if err != nil {
+		return "", err
+	}
+	signature, err := SignSM2(k, HashBySm3(oriMsg))
+	if err != nil {
+		return "", err
+	}
+	return EncodeSign(signature), nil
+}
+
+// xasset国密校验签名完整方法
+// @jsPubKey: json格式SM2 public key
+// @oriMsg: 签名的原始数据
+func XassetVerifySM2(jsPubKey, signature string, oriMsg []byte) (bool, error) {
+	k, err := gmaccount.GetEcdsaPublicKeyFromJson([]byte(jsPubKey))
+	if err != nil {
+		return false, err
+	}
+	sigBytes, err := DecodeSign(signature)
+	if err != nil {
+		return false, err
+	}
+	return VerifySM2(k, sigBytes, HashBySm3(oriMsg))
+}
+
+// XassetSign 按密码学类型签名
+func XassetSign(ct CryptoType, jsPrivtKey string, oriMsg []byte) (string, error) {
+	switch ct {
+	case CryptoTypeNist:
+		return XassetSignECDSA(jsPrivtKey, oriMsg)
+	case CryptoTypeGm:
+		return XassetSignSM2(jsPrivtKey, oriMsg)
+	}
+	return "", ErrCryptoType
+}
+
+// XassetVerify 根据公钥曲线选择验签算法，服务端校验用户签名时使用
+func XassetVerify(jsPubKey, signature string, oriMsg []byte) (bool, error) {
+	ct, err := KeyCryptoType(jsPubKey)
+	if err != nil {
+		return false, err
+	}
+	if ct == CryptoTypeGm {
+		return XassetVerifySM2(jsPubKey, signature, oriMsg)
+	}
+	return XassetVerifyECDSA(jsPubKey, signature, oriMsg)
+}
+
+// 使用SM3做单次哈希运算
+func HashBySm3(data []byte) []byte {
+	return gmhash.HashUsingSM3(data)
+}
+
+// 使用SM2私钥来签名
+func SignSM2(k *ecdsa.PrivateKey, msg []byte) ([]byte, error) {
+	if k == nil {
+		return nil, fmt.Errorf("sign private key unset")
+	}
+
+	signature, err := gmsign.SignECDSA(k, msg)
+	if err != nil {
+		return nil, fmt.Errorf("sm2 sign failed.err:%v", err)
+	}
+
+	return signature, nil
+}
+
+// 使用SM2公钥来验证签名
+func VerifySM2(k *ecdsa.PublicKey, signature, msg []byte) (bool, error) {
+	if k == nil {
+		return false, fmt.Errorf("sign public key unset")
+	}
+
+	result, err := gmsign.VerifyECDSA(k, signature, msg)
+	if err != nil {
+		return false, fmt.Errorf("verify sm2 failed.err:%v", err)
+	}
+
+	return result, nil
+}
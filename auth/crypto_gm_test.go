@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"testing"
+)
+
+func TestNewXchainGmAccount(t *testing.T) {
+	acc, err := NewAccount(CryptoTypeGm, MnemStrgthWeak, MnemLangEN)
+	if err != nil {
+		t.Fatalf("create gm account failed.err:%v", err)
+	}
+	if acc.CryptoType != CryptoTypeGm {
+		t.Fatalf("crypto type error.%s", acc.CryptoType)
+	}
+	if ct, err := KeyCryptoType(acc.PublicKey); err != nil || ct != CryptoTypeGm {
+		t.Fatalf("key crypto type error.ct:%s err:%v", ct, err)
+	}
+
+	rc, err := RetrieveAccount(CryptoTypeGm, acc.Mnemonic, int(MnemLangEN))
+	if err != nil || rc.Address != acc.Address || rc.PrivateKey != acc.PrivateKey {
+		t.Fatalf("retrieve gm account failed.err:%v", err)
+	}
+
+	pub, err := GetEcdsaPubKeyByJsStr(acc.PublicKey)
+	if err != nil {
+		t.Fatalf("parse gm public key failed.err:%v", err)
+	}
+	if addr, err := GetAddrByPubKey(pub); err != nil || addr != acc.Address {
+		t.Fatalf("gm address error.addr:%s err:%v", addr, err)
+	}
+	if ok, _ := VerifyAddrByPubKey(acc.Address, pub); !ok {
+		t.Fatalf("verify gm address failed")
+	}
+
+	if _, err := NewAccount(CryptoType(9), MnemStrgthWeak, MnemLangEN); err != ErrCryptoType {
+		t.Fatalf("want ErrCryptoType, got %v", err)
+	}
+}
+
+func TestXassetSignSM2(t *testing.T) {
+	gmAcc, _ := NewXchainGmAccount(MnemStrgthWeak, MnemLangEN)
+	nistAcc, _ := NewXchainEcdsaAccount(MnemStrgthWeak, MnemLangEN)
+	msg := []byte("hello world")
+
+	for _, acc := range []*Account{gmAcc, nistAcc} {
+		sign, err := acc.Sign(msg)
+		if err != nil {
+			t.Fatalf("sign failed.ct:%s err:%v", acc.CryptoType, err)
+		}
+		if ok, err := XassetVerify(acc.PublicKey, sign, msg); !ok {
+			t.Fatalf("verify failed.ct:%s err:%v", acc.CryptoType, err)
+		}
+		if ok, _ := XassetVerify(acc.PublicKey, sign, []byte("other")); ok {
+			t.Fatalf("verify should fail on other msg.ct:%s", acc.CryptoType)
+		}
+	}
+
+	// 国密签名不能用NIST算法校验
+	sign, _ := XassetSignSM2(gmAcc.PrivateKey, msg)
+	if ok, _ := XassetVerifyECDSA(gmAcc.PublicKey, sign, msg); ok {
+		t.Fatalf("sm2 sign should not pass ecdsa verify")
+	}
+}
+
+func TestGmSoftSigner(t *testing.T) {
+	acc, _ := NewXchainGmAccount(MnemStrgthWeak, MnemLangEN)
+	s, err := NewSoftSigner(&Account{PrivateKey: acc.PrivateKey, CryptoType: CryptoTypeGm})
+	if err != nil {
+		t.Fatalf("new gm soft signer failed.err:%v", err)
+	}
+	if s.GetAddress() != acc.Address {
+		t.Fatalf("gm signer address error")
+	}
+	msg := []byte("xasset gm signer")
+	sign, err := s.Sign(msg)
+	if err != nil {
+		t.Fatalf("sign failed.err:%v", err)
+	}
+	if ok, _ := XassetVerify(s.GetPublicKey(), sign, msg); !ok {
+		t.Fatalf("verify gm soft signer failed")
+	}
+
+	// CryptoType为零值时按私钥曲线推断
+	legacy, err := NewSoftSigner(&Account{Address: acc.Address, PrivateKey: acc.PrivateKey})
+	if err != nil || legacy.GetAddress() != acc.Address {
+		t.Fatalf("legacy gm account should be accepted.err:%v", err)
+	}
+	sign, _ = legacy.Sign(msg)
+	if ok, _ := XassetVerify(legacy.GetPublicKey(), sign, msg); !ok {
+		t.Fatalf("verify legacy gm signer failed")
+	}
+
+	nist, _ := NewXchainEcdsaAccount(MnemStrgthWeak, MnemLangEN)
+	if _, err := NewSoftSigner(&Account{PrivateKey: nist.PrivateKey, CryptoType: CryptoTypeGm}); err != ErrCryptoType {
+		t.Fatalf("want ErrCryptoType, got %v", err)
+	}
+}
+
+func TestGmAccountSignWithoutCryptoType(t *testing.T) {
+	acc, _ := NewXchainGmAccount(MnemStrgthWeak, MnemLangEN)
+	acc.CryptoType = 0
+	msg := []byte("xasset gm account")
+	sign, err := acc.Sign(msg)
+	if err != nil {
+		t.Fatalf("sign failed.err:%v", err)
+	}
+	if ok, _ := XassetVerify(acc.PublicKey, sign, msg); !ok {
+		t.Fatalf("verify gm account sign failed")
+	}
+}
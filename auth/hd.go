@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xuperchain/crypto/client/service/xchain"
+	"github.com/xuperchain/crypto/core/account"
+	"github.com/xuperchain/crypto/core/hdwallet/keychain"
+)
+
+// 分层确定性(HD)账户派生
+//
+// 一个主助记词派生任意多个子账户，备份只需保存主助记词。
+// 子账户路径为 基础路径/序号，最后一级为普通派生。目前仅支持NIST助记词。
+
+const (
+	// 默认基础路径
+	DefaultHDBasePath = "m/44'/0'/0'/0"
+	// 硬化派生起始序号
+	HardenedKeyStart = keychain.HardenedKeyStart
+)
+
+var (
+	ErrHDPath         = errors.New("hd path invalid")
+	ErrHDIndex        = errors.New("hd index must be less than 2^31")
+	ErrHDRange        = errors.New("hd search range invalid")
+	ErrHDAddrNotFound = errors.New("address not derived in range")
+)
+
+// HD钱包，持有基础路径上的扩展私钥
+type HDWallet struct {
+	basePath string
+	base     *keychain.ExtendedKey
+}
+
+// NewHDWallet 由主助记词创建HD钱包，助记词语言自动识别，basePath为空时使用DefaultHDBasePath
+func NewHDWallet(mnemonic string, basePath string) (*HDWallet, error) {
+	if basePath == "" {
+		basePath = DefaultHDBasePath
+	}
+	path, err := ParseHDPath(basePath)
+	if err != nil {
+		return nil, err
+	}
+	info, err := ValidateMnemonic(mnemonic, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	cryptoCli := &xchain.XchainCryptoClient{}
+	js, err := cryptoCli.GenerateMasterKeyByMnemonic(info.Mnemonic, int(info.Lang))
+	if err != nil {
+		return nil, fmt.Errorf("generate master key failed, only nist mnemonic supported")
+	}
+	var key *keychain.ExtendedKey
+	if err := json.Unmarshal([]byte(js), &key); err != nil {
+		return nil, err
+	}
+	for _, i := range path {
+		if key, err = key.Child(i); err != nil {
+			return nil, err
+		}
+	}
+
+	return &HDWallet{basePath: basePath, base: key}, nil
+}
+
+// ParseHDPath 解析 m/44'/0'/0'/0 格式路径，'或h表示硬化派生
+func ParseHDPath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, ErrHDPath
+	}
+	res := make([]uint32, 0, len(parts)-1)
+	for _, p := range parts[1:] {
+		hardened := strings.HasSuffix(p, "'") || strings.HasSuffix(p, "h")
+		if hardened {
+			p = p[:len(p)-1]
+		}
+		n, err := strconv.ParseUint(p, 10, 32)
+		if err != nil || n >= HardenedKeyStart {
+			return nil, ErrHDPath
+		}
+		if hardened {
+			n += HardenedKeyStart
+		}
+		res = append(res, uint32(n))
+	}
+	return res, nil
+}
+
+func (t *HDWallet) BasePath() string {
+	return t.basePath
+}
+
+// Path 子账户的完整路径
+func (t *HDWallet) Path(index uint32) string {
+	return t.basePath + "/" + strconv.FormatUint(uint64(index), 10)
+}
+
+// DeriveAccount 派生序号为index的子账户，子账户没有助记词
+func (t *HDWallet) DeriveAccount(index uint32) (*Account, error) {
+	if index >= HardenedKeyStart {
+		return nil, ErrHDIndex
+	}
+	child, err := t.base.Child(index)
+	if err != nil {
+		return nil, err
+	}
+	key, err := child.ECPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	privKey, err := account.GetEcdsaPrivateKeyJsonFormat(key)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := account.GetEcdsaPublicKeyJsonFormat(key)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := GetAddrByPubKey(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	acc := &Account{
+		Address:    addr,
+		PrivateKey: privKey,
+		PublicKey:  pubKey,
+	}
+
+	return acc, nil
+}
+
+// FindIndex 在[from, to)范围内查找地址对应的序号
+func (t *HDWallet) FindIndex(address string, from, to uint32) (uint32, error) {
+	if from >= to || to > HardenedKeyStart {
+		return 0, ErrHDRange
+	}
+	for i := from; i < to; i++ {
+		acc, err := t.DeriveAccount(i)
+		if err != nil {
+			return 0, err
+		}
+		if acc.Address == address {
+			return i, nil
+		}
+	}
+	return 0, ErrHDAddrNotFound
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"testing"
+)
+
+func TestHDWallet(t *testing.T) {
+	master, err := NewXchainEcdsaAccount(MnemStrgthMedium, MnemLangEN)
+	if err != nil {
+		t.Fatalf("create account failed.err:%v", err)
+	}
+	w, err := NewHDWallet(master.Mnemonic, "")
+	if err != nil {
+		t.Fatalf("new hd wallet failed.err:%v", err)
+	}
+	if w.Path(7) != DefaultHDBasePath+"/7" {
+		t.Fatalf("path error.%s", w.Path(7))
+	}
+
+	seen := map[string]bool{master.Address: true}
+	var accs []*Account
+	for i := uint32(0); i < 5; i++ {
+		acc, err := w.DeriveAccount(i)
+		if err != nil {
+			t.Fatalf("derive %d failed.err:%v", i, err)
+		}
+		if seen[acc.Address] {
+			t.Fatalf("derived address duplicated.%d", i)
+		}
+		seen[acc.Address] = true
+		accs = append(accs, acc)
+
+		msg := []byte("hd child")
+		sign, err := acc.Sign(msg)
+		if err != nil {
+			t.Fatalf("sign failed.err:%v", err)
+		}
+		if ok, _ := XassetVerify(acc.PublicKey, sign, msg); !ok {
+			t.Fatalf("child %d sign invalid", i)
+		}
+	}
+
+	// 同一助记词派生结果确定
+	w2, _ := NewHDWallet(master.Mnemonic, DefaultHDBasePath)
+	again, _ := w2.DeriveAccount(3)
+	if again.Address != accs[3].Address || again.PrivateKey != accs[3].PrivateKey {
+		t.Fatalf("derive not deterministic")
+	}
+	other, _ := NewHDWallet(master.Mnemonic, "m/44'/0'/1'/0")
+	if acc, _ := other.DeriveAccount(3); acc.Address == accs[3].Address {
+		t.Fatalf("different base path should derive different address")
+	}
+
+	if i, err := w.FindIndex(accs[4].Address, 0, 10); err != nil || i != 4 {
+		t.Fatalf("find index failed.%d err:%v", i, err)
+	}
+	if _, err := w.FindIndex(master.Address, 0, 10); err != ErrHDAddrNotFound {
+		t.Fatalf("want ErrHDAddrNotFound, got %v", err)
+	}
+	if _, err := w.FindIndex(accs[4].Address, 5, 5); err != ErrHDRange {
+		t.Fatalf("want ErrHDRange, got %v", err)
+	}
+	if _, err := w.DeriveAccount(HardenedKeyStart); err != ErrHDIndex {
+		t.Fatalf("want ErrHDIndex, got %v", err)
+	}
+	// 中文助记词按识别出的语言派生
+	cn, _ := NewXchainEcdsaAccount(MnemStrgthMedium, MnemLangCN)
+	wcn, err := NewHDWallet(cn.Mnemonic, "")
+	if err != nil {
+		t.Fatalf("new cn hd wallet failed.err:%v", err)
+	}
+	if _, err := wcn.DeriveAccount(0); err != nil {
+		t.Fatalf("derive cn child failed.err:%v", err)
+	}
+}
+
+func TestParseHDPath(t *testing.T) {
+	p, err := ParseHDPath("m/44'/1h/2")
+	if err != nil || len(p) != 3 || p[0] != HardenedKeyStart+44 || p[1] != HardenedKeyStart+1 || p[2] != 2 {
+		t.Fatalf("parse path failed.%v err:%v", p, err)
+	}
+	for _, s := range []string{"", "44'/0", "m/x", "m/2147483648"} {
+		if _, err := ParseHDPath(s); err != ErrHDPath {
+			t.Fatalf("path %q want ErrHDPath, got %v", s, err)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/xuperchain/crypto/core/account"
+	gmaccount "github.com/xuperchain/crypto/gm/account"
+)
+
+var (
+	ErrSignerUnset    = errors.New("signer unset")
+	ErrSignerKeyUnset = errors.New("signer private key unset")
+	ErrSignerMismatch = errors.New("signer address does not match public key")
+)
+
+// 签名者，业务代码通过该接口签名，不直接接触私钥
+// 私钥可以放在内存、本地密钥库或独立的签名进程中
+type Signer interface {
+	// 钱包地址
+	GetAddress() string
+	// json格式公钥
+	GetPublicKey() string
+	// 对原始消息签名，内部做SHA256，返回16进制签名，与XassetSignECDSA一致
+	Sign(msg []byte) (string, error)
+}
+
+// *Account 实现了Signer，每次签名都会重新解析私钥
+func (t *Account) GetAddress() string {
+	return t.Address
+}
+
+func (t *Account) GetPublicKey() string {
+	return t.PublicKey
+}
+
+func (t *Account) Sign(msg []byte) (string, error) {
+	if t.PrivateKey == "" {
+		return "", ErrSignerKeyUnset
+	}
+	// 旧版json或手工构造的账户CryptoType为零值，按私钥中的曲线签名
+	ct := t.CryptoType
+	if ct == CryptoTypeNist {
+		if kct, err := KeyCryptoType(t.PrivateKey); err == nil {
+			ct = kct
+		}
+	}
+	return XassetSign(ct, t.PrivateKey, msg)
+}
+
+// SignerValid 检查签名者是否设置，兼容值为nil的*Account
+func SignerValid(s Signer) error {
+	if s == nil {
+		return ErrSignerUnset
+	}
+	if acc, ok := s.(*Account); ok && acc == nil {
+		return ErrSignerUnset
+	}
+	return nil
+}
+
+// 软件签名者，创建时解析并校验私钥，之后签名不再重复解析
+type SoftSigner struct {
+	ct     CryptoType
+	addr   string
+	pubKey string
+	key    *ecdsa.PrivateKey
+}
+
+// NewSoftSigner 使用账户创建软件签名者，地址和公钥为空时由私钥推导，否则校验是否匹配
+func NewSoftSigner(acc *Account) (*SoftSigner, error) {
+	if acc == nil {
+		return nil, ErrSignerUnset
+	}
+	if acc.PrivateKey == "" {
+		return nil, ErrSignerKeyUnset
+	}
+	key, err := GetEcdsaPriKeyByJsStr(acc.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	// 按私钥曲线确定密码学类型，CryptoType为零值时兼容旧版json或手工构造的国密账户
+	ct := CryptoTypeNist
+	if isGmKey(&key.PublicKey) {
+		ct = CryptoTypeGm
+	}
+	if acc.CryptoType == CryptoTypeGm && ct != CryptoTypeGm {
+		return nil, ErrCryptoType
+	}
+	addr, err := GetAddrByPubKey(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if acc.Address != "" && acc.Address != addr {
+		return nil, ErrSignerMismatch
+	}
+	pubKey := acc.PublicKey
+	if pubKey == "" {
+		if ct == CryptoTypeGm {
+			pubKey, err = gmaccount.GetEcdsaPublicKeyJsonFormat(key)
+		} else {
+			pubKey, err = account.GetEcdsaPublicKeyJsonFormat(key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		pub, err := GetEcdsaPubKeyByJsStr(pubKey)
+		if err != nil {
+			return nil, err
+		}
+		if pub.X.Cmp(key.X) != 0 || pub.Y.Cmp(key.Y) != 0 {
+			return nil, ErrSignerMismatch
+		}
+	}
+
+	return &SoftSigner{ct: ct, addr: addr, pubKey: pubKey, key: key}, nil
+}
+
+func (t *SoftSigner) GetAddress() string {
+	return t.addr
+}
+
+func (t *SoftSigner) GetPublicKey() string {
+	return t.pubKey
+}
+
+func (t *SoftSigner) Sign(msg []byte) (string, error) {
+	var signature []byte
+	var err error
+	if t.ct == CryptoTypeGm {
+		signature, err = SignSM2(t.key, HashBySm3(msg))
+	} else {
+		signature, err = SignECDSA(t.key, HashBySha256(msg))
+	}
+	if err != nil {
+		return "", err
+	}
+	return EncodeSign(signature), nil
+}
+
+// 只有地址的签名者，用于在线机器准备待离线签名的请求，调用Sign会失败
+type AddrOnly string
+
+func (t AddrOnly) GetAddress() string {
+	return string(t)
+}
+
+func (t AddrOnly) GetPublicKey() string {
+	return ""
+}
+
+func (t AddrOnly) Sign(msg []byte) (string, error) {
+	return "", ErrSignerKeyUnset
+}
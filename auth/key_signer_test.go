@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"testing"
+)
+
+func TestSoftSigner(t *testing.T) {
+	acc, err := NewXchainEcdsaAccount(MnemStrgthWeak, MnemLangEN)
+	if err != nil {
+		t.Fatalf("create account failed.err:%v", err)
+	}
+	s, err := NewSoftSigner(acc)
+	if err != nil {
+		t.Fatalf("new soft signer failed.err:%v", err)
+	}
+	if s.GetAddress() != acc.Address || s.GetPublicKey() != acc.PublicKey {
+		t.Fatalf("signer identity error")
+	}
+
+	msg := []byte("xasset signer")
+	for _, signer := range []Signer{acc, s} {
+		sign, err := signer.Sign(msg)
+		if err != nil {
+			t.Fatalf("sign failed.err:%v", err)
+		}
+		if ok, _ := XassetVerifyECDSA(signer.GetPublicKey(), sign, msg); !ok {
+			t.Fatalf("verify sign failed")
+		}
+	}
+
+	// 地址和公钥为空时由私钥推导
+	derived, err := NewSoftSigner(&Account{PrivateKey: acc.PrivateKey})
+	if err != nil || derived.GetAddress() != acc.Address {
+		t.Fatalf("derive identity failed.err:%v", err)
+	}
+	sign, _ := derived.Sign(msg)
+	if ok, _ := XassetVerifyECDSA(derived.GetPublicKey(), sign, msg); !ok {
+		t.Fatalf("derived public key invalid")
+	}
+
+	other, _ := NewXchainEcdsaAccount(MnemStrgthWeak, MnemLangEN)
+	if _, err := NewSoftSigner(&Account{Address: other.Address, PrivateKey: acc.PrivateKey}); err != ErrSignerMismatch {
+		t.Fatalf("want ErrSignerMismatch, got %v", err)
+	}
+	if _, err := NewSoftSigner(&Account{PrivateKey: acc.PrivateKey, PublicKey: other.PublicKey}); err != ErrSignerMismatch {
+		t.Fatalf("want ErrSignerMismatch, got %v", err)
+	}
+	if _, err := (&Account{Address: acc.Address}).Sign(msg); err != ErrSignerKeyUnset {
+		t.Fatalf("want ErrSignerKeyUnset, got %v", err)
+	}
+}
+
+func TestSignerValid(t *testing.T) {
+	var acc *Account
+	if SignerValid(nil) != ErrSignerUnset || SignerValid(acc) != ErrSignerUnset {
+		t.Fatal("nil signer should be invalid")
+	}
+	if SignerValid(&Account{}) != nil {
+		t.Fatal("account signer should be valid")
+	}
+}
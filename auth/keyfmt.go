@@ -0,0 +1,354 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/xuperchain/crypto/core/account"
+	"github.com/xuperchain/crypto/gm/gmsm/sm2"
+)
+
+// 密钥格式，用于与PEM、JWK等外部工具互通
+type KeyFormat string
+
+const (
+	// xuperchain json格式，即 Account.PrivateKey/PublicKey
+	KeyFormatJson KeyFormat = "json"
+	// PKCS#8私钥PEM，"PRIVATE KEY"
+	KeyFormatPkcs8 KeyFormat = "pkcs8"
+	// SEC1私钥PEM，"EC PRIVATE KEY"
+	KeyFormatSec1 KeyFormat = "sec1"
+	// 公钥为PKIX PEM "PUBLIC KEY"；导入私钥时自动识别PKCS#8和SEC1
+	KeyFormatPem KeyFormat = "pem"
+	// 私钥为32字节标量，公钥为非压缩点 04||X||Y
+	KeyFormatHex KeyFormat = "hex"
+	// RFC 7517 JWK
+	KeyFormatJwk KeyFormat = "jwk"
+)
+
+const (
+	pemTypePkcs8  = "PRIVATE KEY"
+	pemTypeSec1   = "EC PRIVATE KEY"
+	pemTypePublic = "PUBLIC KEY"
+)
+
+var (
+	ErrKeyFormat      = errors.New("key format unsupported")
+	ErrKeyFormatCurve = errors.New("key format unsupported for gm curve")
+	ErrKeyData        = errors.New("key data invalid")
+)
+
+// JWK椭圆曲线密钥
+type Jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	D   string `json:"d,omitempty"`
+}
+
+// ExportPrivateKey 将json格式私钥转换为指定格式，PEM和JWK仅支持NIST曲线
+func ExportPrivateKey(jsPrivtKey string, format KeyFormat) (string, error) {
+	key, err := GetEcdsaPriKeyByJsStr(jsPrivtKey)
+	if err != nil {
+		return "", err
+	}
+	if format != KeyFormatJson && format != KeyFormatHex && isGmKey(&key.PublicKey) {
+		return "", ErrKeyFormatCurve
+	}
+
+	switch format {
+	case KeyFormatJson:
+		return account.GetEcdsaPrivateKeyJsonFormat(key)
+	case KeyFormatPkcs8, KeyFormatPem:
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return "", err
+		}
+		return string(pem.EncodeToMemory(&pem.Block{Type: pemTypePkcs8, Bytes: der})), nil
+	case KeyFormatSec1:
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return "", err
+		}
+		return string(pem.EncodeToMemory(&pem.Block{Type: pemTypeSec1, Bytes: der})), nil
+	case KeyFormatHex:
+		return hex.EncodeToString(scalarBytes(key.Curve, key.D)), nil
+	case KeyFormatJwk:
+		jwk := publicJwk(&key.PublicKey)
+		jwk.D = Base64UrlEncode(scalarBytes(key.Curve, key.D))
+		data, err := json.Marshal(jwk)
+		return string(data), err
+	}
+	return "", ErrKeyFormat
+}
+
+// ExportPublicKey 将json格式公钥转换为指定格式，PEM和JWK仅支持NIST曲线
+func ExportPublicKey(jsPubKey string, format KeyFormat) (string, error) {
+	key, err := GetEcdsaPubKeyByJsStr(jsPubKey)
+	if err != nil {
+		return "", err
+	}
+	if format != KeyFormatJson && format != KeyFormatHex && isGmKey(key) {
+		return "", ErrKeyFormatCurve
+	}
+
+	switch format {
+	case KeyFormatJson:
+		return account.GetEcdsaPublicKeyJsonFormatFromPublicKey(key)
+	case KeyFormatPem:
+		der, err := x509.MarshalPKIXPublicKey(key)
+		if err != nil {
+			return "", err
+		}
+		return string(pem.EncodeToMemory(&pem.Block{Type: pemTypePublic, Bytes: der})), nil
+	case KeyFormatHex:
+		return hex.EncodeToString(pointBytes(key)), nil
+	case KeyFormatJwk:
+		data, err := json.Marshal(publicJwk(key))
+		return string(data), err
+	}
+	return "", ErrKeyFormat
+}
+
+// ImportPrivateKey 从指定格式导入私钥，返回的账户没有助记词
+// ct只在hex格式时用于选择曲线，其他格式自带曲线信息
+func ImportPrivateKey(data string, format KeyFormat, ct CryptoType) (*Account, error) {
+	data = strings.TrimSpace(data)
+	var key *ecdsa.PrivateKey
+	var err error
+	switch format {
+	case KeyFormatJson:
+		key, err = GetEcdsaPriKeyByJsStr(data)
+	case KeyFormatPkcs8, KeyFormatSec1, KeyFormatPem:
+		key, err = parsePrivatePem(data)
+	case KeyFormatHex:
+		key, err = parsePrivateHex(data, ct)
+	case KeyFormatJwk:
+		key, err = parsePrivateJwk(data)
+	default:
+		err = ErrKeyFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	privKey, err := account.GetEcdsaPrivateKeyJsonFormat(key)
+	if err != nil {
+		return nil, err
+	}
+	acc, err := accountFromPubKey(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	acc.PrivateKey = privKey
+	return acc, nil
+}
+
+// ImportPublicKey 从指定格式导入公钥，返回只有地址和公钥的账户
+func ImportPublicKey(data string, format KeyFormat, ct CryptoType) (*Account, error) {
+	data = strings.TrimSpace(data)
+	var key *ecdsa.PublicKey
+	var err error
+	switch format {
+	case KeyFormatJson:
+		key, err = GetEcdsaPubKeyByJsStr(data)
+	case KeyFormatPem:
+		block, _ := pem.Decode([]byte(data))
+		if block == nil || block.Type != pemTypePublic {
+			return nil, ErrKeyData
+		}
+		var pub interface{}
+		if pub, err = x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+			var ok bool
+			if key, ok = pub.(*ecdsa.PublicKey); !ok {
+				err = ErrKeyData
+			}
+		}
+	case KeyFormatHex:
+		key, err = parsePublicHex(data, ct)
+	case KeyFormatJwk:
+		var jwk Jwk
+		if err = json.Unmarshal([]byte(data), &jwk); err == nil {
+			key, err = jwk.publicKey()
+		}
+	default:
+		err = ErrKeyFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	return accountFromPubKey(key)
+}
+
+func accountFromPubKey(key *ecdsa.PublicKey) (*Account, error) {
+	pubKey, err := account.GetEcdsaPublicKeyJsonFormatFromPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := GetAddrByPubKey(key)
+	if err != nil {
+		return nil, err
+	}
+	acc := &Account{
+		Address:   addr,
+		PublicKey: pubKey,
+	}
+	if isGmKey(key) {
+		acc.CryptoType = CryptoTypeGm
+	}
+	return acc, nil
+}
+
+func curveOf(ct CryptoType) (elliptic.Curve, error) {
+	switch ct {
+	case CryptoTypeNist:
+		return elliptic.P256(), nil
+	case CryptoTypeGm:
+		return sm2.P256Sm2(), nil
+	}
+	return nil, ErrCryptoType
+}
+
+func parsePrivatePem(data string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, ErrKeyData
+	}
+	switch block.Type {
+	case pemTypeSec1:
+		return x509.ParseECPrivateKey(block.Bytes)
+	case pemTypePkcs8:
+		k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		if key, ok := k.(*ecdsa.PrivateKey); ok {
+			return key, nil
+		}
+	}
+	return nil, ErrKeyData
+}
+
+func parsePrivateHex(data string, ct CryptoType) (*ecdsa.PrivateKey, error) {
+	curve, err := curveOf(ct)
+	if err != nil {
+		return nil, err
+	}
+	d, err := hex.DecodeString(data)
+	if err != nil {
+		return nil, ErrKeyData
+	}
+	return privateFromScalar(curve, d)
+}
+
+func parsePublicHex(data string, ct CryptoType) (*ecdsa.PublicKey, error) {
+	curve, err := curveOf(ct)
+	if err != nil {
+		return nil, err
+	}
+	b, err := hex.DecodeString(data)
+	if err != nil {
+		return nil, ErrKeyData
+	}
+	size := (curve.Params().BitSize + 7) / 8
+	if len(b) != 1+2*size || b[0] != 4 {
+		return nil, ErrKeyData
+	}
+	return publicFromXY(curve, b[1:1+size], b[1+size:])
+}
+
+func parsePrivateJwk(data string) (*ecdsa.PrivateKey, error) {
+	var jwk Jwk
+	if err := json.Unmarshal([]byte(data), &jwk); err != nil {
+		return nil, ErrKeyData
+	}
+	pub, err := jwk.publicKey()
+	if err != nil {
+		return nil, err
+	}
+	d, err := Base64UrlDecode(jwk.D)
+	if err != nil || len(d) == 0 {
+		return nil, ErrKeyData
+	}
+	key, err := privateFromScalar(pub.Curve, d)
+	if err != nil {
+		return nil, err
+	}
+	if key.X.Cmp(pub.X) != 0 || key.Y.Cmp(pub.Y) != 0 {
+		return nil, ErrKeyData
+	}
+	return key, nil
+}
+
+func (t *Jwk) publicKey() (*ecdsa.PublicKey, error) {
+	if t.Kty != "EC" || t.Crv != "P-256" {
+		return nil, ErrKeyFormat
+	}
+	x, err := Base64UrlDecode(t.X)
+	if err != nil {
+		return nil, ErrKeyData
+	}
+	y, err := Base64UrlDecode(t.Y)
+	if err != nil {
+		return nil, ErrKeyData
+	}
+	return publicFromXY(elliptic.P256(), x, y)
+}
+
+func publicJwk(key *ecdsa.PublicKey) *Jwk {
+	return &Jwk{
+		Kty: "EC",
+		Crv: key.Params().Name,
+		X:   Base64UrlEncode(scalarBytes(key.Curve, key.X)),
+		Y:   Base64UrlEncode(scalarBytes(key.Curve, key.Y)),
+	}
+}
+
+func privateFromScalar(curve elliptic.Curve, d []byte) (*ecdsa.PrivateKey, error) {
+	k := new(big.Int).SetBytes(d)
+	if k.Sign() == 0 || k.Cmp(curve.Params().N) >= 0 {
+		return nil, ErrKeyData
+	}
+	key := &ecdsa.PrivateKey{D: k}
+	key.Curve = curve
+	key.X, key.Y = curve.ScalarBaseMult(scalarBytes(curve, k))
+	return key, nil
+}
+
+func publicFromXY(curve elliptic.Curve, x, y []byte) (*ecdsa.PublicKey, error) {
+	key := &ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}
+	if !curve.IsOnCurve(key.X, key.Y) {
+		return nil, ErrKeyData
+	}
+	return key, nil
+}
+
+// 按曲线长度左补零的定长编码
+func scalarBytes(curve elliptic.Curve, n *big.Int) []byte {
+	size := (curve.Params().BitSize + 7) / 8
+	b := n.Bytes()
+	if len(b) >= size {
+		return b
+	}
+	res := make([]byte, size)
+	copy(res[size-len(b):], b)
+	return res
+}
+
+func pointBytes(key *ecdsa.PublicKey) []byte {
+	res := []byte{4}
+	res = append(res, scalarBytes(key.Curve, key.X)...)
+	return append(res, scalarBytes(key.Curve, key.Y)...)
+}
@@ -0,0 +1,445 @@
+// Package keystore 使用口令加密保存账户的本地密钥库
+//
+// 每个账户保存为目录下的 <address>.json 文件，私钥、公钥和助记词经KDF派生的
+// 密钥使用AES-256-GCM加密，地址作为附加认证数据，文件内容不包含任何明文秘密。
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+)
+
+const (
+	Version = 1
+
+	KDFScrypt = "scrypt"
+	KDFPBKDF2 = "pbkdf2"
+
+	CipherAESGCM = "aes-256-gcm"
+
+	fileExt  = ".json"
+	keyLen   = 32
+	saltLen  = 32
+	filePerm = 0600
+	dirPerm  = 0700
+
+	// KDF参数上限，防止篡改的文件导致大量内存或CPU消耗
+	// scrypt内存约为128*N*r字节，上限1GB
+	maxScryptN   = 1 << 20
+	maxScryptNR  = 1 << 23
+	maxScryptRP  = 1 << 6
+	maxPBKDF2Itr = 10000000
+)
+
+var (
+	ErrNoAccount      = errors.New("keystore account not found")
+	ErrExists         = errors.New("keystore account already exists")
+	ErrDecrypt        = errors.New("keystore decrypt failed, wrong passphrase or corrupted file")
+	ErrEmptyPass      = errors.New("keystore passphrase empty")
+	ErrKDF            = errors.New("keystore kdf unsupported")
+	ErrCipher         = errors.New("keystore cipher unsupported")
+	ErrVersion        = errors.New("keystore version unsupported")
+	ErrAccountInvalid = errors.New("keystore account invalid")
+)
+
+// KDF参数
+type Options struct {
+	KDF string
+	// scrypt参数
+	ScryptN int
+	ScryptR int
+	ScryptP int
+	// pbkdf2迭代次数
+	PBKDF2Iter int
+}
+
+// 标准强度，单次派生约数百毫秒
+var StandardOptions = Options{KDF: KDFScrypt, ScryptN: 1 << 18, ScryptR: 8, ScryptP: 1}
+
+// 轻量强度，适用于移动端或测试
+var LightOptions = Options{KDF: KDFScrypt, ScryptN: 1 << 12, ScryptR: 8, ScryptP: 6}
+
+// PBKDF2-HMAC-SHA256
+var PBKDF2Options = Options{KDF: KDFPBKDF2, PBKDF2Iter: 600000}
+
+type kdfParams struct {
+	N     int    `json:"n,omitempty"`
+	R     int    `json:"r,omitempty"`
+	P     int    `json:"p,omitempty"`
+	C     int    `json:"c,omitempty"`
+	Prf   string `json:"prf,omitempty"`
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+}
+
+type cryptoJSON struct {
+	Cipher     string    `json:"cipher"`
+	CipherText string    `json:"ciphertext"`
+	Nonce      string    `json:"nonce"`
+	KDF        string    `json:"kdf"`
+	KDFParams  kdfParams `json:"kdfparams"`
+}
+
+type keyJSON struct {
+	Version   int        `json:"version"`
+	Address   string     `json:"address"`
+	CreatedAt int64      `json:"created_at"`
+	Crypto    cryptoJSON `json:"crypto"`
+}
+
+// 密文内的账户信息
+type secretJSON struct {
+	PrivateKey string `json:"private_key"`
+	PublicKey  string `json:"public_key"`
+	Mnemonic   string `json:"mnemonic,omitempty"`
+	// 密码学类型
+	CryptoType auth.CryptoType `json:"crypto_type,omitempty"`
+}
+
+// Encrypt 加密账户，opts为空时使用StandardOptions
+func Encrypt(acc *auth.Account, passphrase string, opts *Options) ([]byte, error) {
+	if acc == nil || acc.Address == "" || acc.PrivateKey == "" {
+		return nil, ErrAccountInvalid
+	}
+	if passphrase == "" {
+		return nil, ErrEmptyPass
+	}
+	if opts == nil {
+		opts = &StandardOptions
+	}
+
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	params := kdfParams{DKLen: keyLen, Salt: hex.EncodeToString(salt)}
+	switch opts.KDF {
+	case KDFScrypt:
+		params.N, params.R, params.P = opts.ScryptN, opts.ScryptR, opts.ScryptP
+	case KDFPBKDF2:
+		params.C, params.Prf = opts.PBKDF2Iter, "hmac-sha256"
+	default:
+		return nil, ErrKDF
+	}
+	key, err := deriveKey(opts.KDF, &params, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	plain, err := json.Marshal(&secretJSON{
+		PrivateKey: acc.PrivateKey,
+		PublicKey:  acc.PublicKey,
+		Mnemonic:   acc.Mnemonic,
+		CryptoType: acc.CryptoType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	ct := gcm.Seal(nil, nonce, plain, []byte(acc.Address))
+
+	return json.MarshalIndent(&keyJSON{
+		Version:   Version,
+		Address:   acc.Address,
+		CreatedAt: time.Now().Unix(),
+		Crypto: cryptoJSON{
+			Cipher:     CipherAESGCM,
+			CipherText: hex.EncodeToString(ct),
+			Nonce:      hex.EncodeToString(nonce),
+			KDF:        opts.KDF,
+			KDFParams:  params,
+		},
+	}, "", "  ")
+}
+
+// Decrypt 解密账户
+func Decrypt(data []byte, passphrase string) (*auth.Account, error) {
+	var k keyJSON
+	if err := json.Unmarshal(data, &k); err != nil {
+		return nil, ErrAccountInvalid
+	}
+	if k.Version != Version {
+		return nil, ErrVersion
+	}
+	if k.Crypto.Cipher != CipherAESGCM {
+		return nil, ErrCipher
+	}
+	key, err := deriveKey(k.Crypto.KDF, &k.Crypto.KDFParams, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hex.DecodeString(k.Crypto.Nonce)
+	if err != nil {
+		return nil, ErrAccountInvalid
+	}
+	ct, err := hex.DecodeString(k.Crypto.CipherText)
+	if err != nil {
+		return nil, ErrAccountInvalid
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, ErrAccountInvalid
+	}
+	plain, err := gcm.Open(nil, nonce, ct, []byte(k.Address))
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+
+	var s secretJSON
+	if err := json.Unmarshal(plain, &s); err != nil {
+		return nil, ErrAccountInvalid
+	}
+	return &auth.Account{
+		Address:    k.Address,
+		PrivateKey: s.PrivateKey,
+		PublicKey:  s.PublicKey,
+		Mnemonic:   s.Mnemonic,
+		CryptoType: s.CryptoType,
+	}, nil
+}
+
+func deriveKey(kdf string, p *kdfParams, passphrase string) ([]byte, error) {
+	salt, err := hex.DecodeString(p.Salt)
+	if err != nil || len(salt) == 0 || p.DKLen != keyLen {
+		return nil, ErrAccountInvalid
+	}
+	switch kdf {
+	case KDFScrypt:
+		if p.N <= 0 || p.R <= 0 || p.P <= 0 || p.N > maxScryptN || p.R*p.P > maxScryptRP || p.N*p.R > maxScryptNR {
+			return nil, ErrKDF
+		}
+		key, err := scrypt.Key([]byte(passphrase), salt, p.N, p.R, p.P, p.DKLen)
+		if err != nil {
+			return nil, fmt.Errorf("scrypt derive key failed.err:%v", err)
+		}
+		return key, nil
+	case KDFPBKDF2:
+		if p.Prf != "hmac-sha256" || p.C <= 0 || p.C > maxPBKDF2Itr {
+			return nil, ErrKDF
+		}
+		return pbkdf2.Key([]byte(passphrase), salt, p.C, p.DKLen, sha256.New), nil
+	}
+	return nil, ErrKDF
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// 密钥库条目
+type Entry struct {
+	Address   string `json:"address"`
+	Path      string `json:"path"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// 本地密钥库
+type KeyStore struct {
+	dir  string
+	opts Options
+}
+
+// New 打开目录下的密钥库，目录不存在时创建，opts为空时使用StandardOptions
+func New(dir string, opts *Options) (*KeyStore, error) {
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &StandardOptions
+	}
+	return &KeyStore{dir: dir, opts: *opts}, nil
+}
+
+func (t *KeyStore) Dir() string {
+	return t.dir
+}
+
+// Import 加密保存账户，私钥与地址不匹配时返回ErrAccountInvalid，地址已存在时返回ErrExists
+func (t *KeyStore) Import(acc *auth.Account, passphrase string) (*Entry, error) {
+	if acc == nil || !validAddr(acc.Address) {
+		return nil, ErrAccountInvalid
+	}
+	if _, err := auth.NewSoftSigner(acc); err != nil {
+		return nil, ErrAccountInvalid
+	}
+	data, err := Encrypt(acc, passphrase, &t.opts)
+	if err != nil {
+		return nil, err
+	}
+	path := t.path(acc.Address)
+	if err := createFile(path, data); err != nil {
+		return nil, err
+	}
+	return &Entry{Address: acc.Address, Path: path, CreatedAt: time.Now().Unix()}, nil
+}
+
+// List 列出全部账户，按地址排序
+func (t *KeyStore) List() ([]*Entry, error) {
+	files, err := ioutil.ReadDir(t.dir)
+	if err != nil {
+		return nil, err
+	}
+	var entries []*Entry
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), fileExt) {
+			continue
+		}
+		path := filepath.Join(t.dir, f.Name())
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var k keyJSON
+		if err := json.Unmarshal(data, &k); err != nil || k.Address == "" {
+			continue
+		}
+		entries = append(entries, &Entry{Address: k.Address, Path: path, CreatedAt: k.CreatedAt})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Address < entries[j].Address })
+	return entries, nil
+}
+
+// Has 地址是否存在
+func (t *KeyStore) Has(addr string) bool {
+	if !validAddr(addr) {
+		return false
+	}
+	_, err := os.Stat(t.path(addr))
+	return err == nil
+}
+
+// Load 按地址加载并解密账户
+func (t *KeyStore) Load(addr, passphrase string) (*auth.Account, error) {
+	data, err := t.read(addr)
+	if err != nil {
+		return nil, err
+	}
+	acc, err := Decrypt(data, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if acc.Address != addr {
+		return nil, ErrAccountInvalid
+	}
+	return acc, nil
+}
+
+// ChangePassphrase 使用新口令重新加密
+func (t *KeyStore) ChangePassphrase(addr, oldPass, newPass string) error {
+	acc, err := t.Load(addr, oldPass)
+	if err != nil {
+		return err
+	}
+	data, err := Encrypt(acc, newPass, &t.opts)
+	if err != nil {
+		return err
+	}
+	return writeFile(t.path(addr), data)
+}
+
+// Delete 校验口令后删除账户
+func (t *KeyStore) Delete(addr, passphrase string) error {
+	if _, err := t.Load(addr, passphrase); err != nil {
+		return err
+	}
+	return os.Remove(t.path(addr))
+}
+
+func (t *KeyStore) read(addr string) ([]byte, error) {
+	if !validAddr(addr) {
+		return nil, ErrNoAccount
+	}
+	data, err := ioutil.ReadFile(t.path(addr))
+	if os.IsNotExist(err) {
+		return nil, ErrNoAccount
+	}
+	return data, err
+}
+
+func (t *KeyStore) path(addr string) string {
+	return filepath.Join(t.dir, addr+fileExt)
+}
+
+// 地址用作文件名，拒绝路径分隔符等字符
+func validAddr(addr string) bool {
+	if addr == "" {
+		return false
+	}
+	for _, c := range addr {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// 以O_EXCL创建新文件，并发导入同一地址时只有一个成功
+func createFile(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, filePerm)
+	if os.IsExist(err) {
+		return ErrExists
+	}
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+	}
+	return err
+}
+
+// 先写临时文件再重命名，避免写入中断损坏原文件
+func writeFile(path string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".tmp-"+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(filePerm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,165 @@
+package keystore
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+)
+
+// 测试使用低强度参数
+var testOptions = Options{KDF: KDFScrypt, ScryptN: 1 << 10, ScryptR: 8, ScryptP: 1}
+
+func newTestStore(t *testing.T, opts *Options) *KeyStore {
+	dir, err := ioutil.TempDir("", "keystore")
+	if err != nil {
+		t.Fatalf("create temp dir failed.err:%v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	ks, err := New(dir, opts)
+	if err != nil {
+		t.Fatalf("new keystore failed.err:%v", err)
+	}
+	return ks
+}
+
+func TestKeyStore(t *testing.T) {
+	acc, err := auth.NewXchainEcdsaAccount(auth.MnemStrgthWeak, auth.MnemLangEN)
+	if err != nil {
+		t.Fatalf("create account failed.err:%v", err)
+	}
+	ks := newTestStore(t, &testOptions)
+
+	entry, err := ks.Import(acc, "pass1")
+	if err != nil {
+		t.Fatalf("import failed.err:%v", err)
+	}
+	if _, err := ks.Import(acc, "pass1"); err != ErrExists {
+		t.Fatalf("want ErrExists, got %v", err)
+	}
+
+	// 文件中不包含明文秘密
+	data, _ := ioutil.ReadFile(entry.Path)
+	for _, secret := range []string{acc.PrivateKey, acc.Mnemonic} {
+		if strings.Contains(string(data), secret) {
+			t.Fatalf("plaintext secret found in keystore file")
+		}
+	}
+	if fi, _ := os.Stat(entry.Path); fi.Mode().Perm() != filePerm {
+		t.Fatalf("file perm error.%v", fi.Mode())
+	}
+
+	list, err := ks.List()
+	if err != nil || len(list) != 1 || list[0].Address != acc.Address {
+		t.Fatalf("list error.%+v err:%v", list, err)
+	}
+
+	loaded, err := ks.Load(acc.Address, "pass1")
+	if err != nil || *loaded != *acc {
+		t.Fatalf("load error.err:%v", err)
+	}
+	if _, err := ks.Load(acc.Address, "wrong"); err != ErrDecrypt {
+		t.Fatalf("want ErrDecrypt, got %v", err)
+	}
+	if _, err := ks.Load("../etc/passwd", "pass1"); err != ErrNoAccount {
+		t.Fatalf("want ErrNoAccount, got %v", err)
+	}
+
+	if err := ks.ChangePassphrase(acc.Address, "pass1", "pass2"); err != nil {
+		t.Fatalf("change passphrase failed.err:%v", err)
+	}
+	if _, err := ks.Load(acc.Address, "pass1"); err != ErrDecrypt {
+		t.Fatalf("old passphrase should fail.err:%v", err)
+	}
+
+	if err := ks.Delete(acc.Address, "pass1"); err != ErrDecrypt {
+		t.Fatalf("delete with wrong passphrase should fail.err:%v", err)
+	}
+	if err := ks.Delete(acc.Address, "pass2"); err != nil {
+		t.Fatalf("delete failed.err:%v", err)
+	}
+	if ks.Has(acc.Address) {
+		t.Fatal("account should be deleted")
+	}
+}
+
+func TestPBKDF2AndTamper(t *testing.T) {
+	acc, _ := auth.NewXchainEcdsaAccount(auth.MnemStrgthWeak, auth.MnemLangCN)
+	data, err := Encrypt(acc, "pass", &Options{KDF: KDFPBKDF2, PBKDF2Iter: 1000})
+	if err != nil {
+		t.Fatalf("encrypt failed.err:%v", err)
+	}
+	got, err := Decrypt(data, "pass")
+	if err != nil || *got != *acc {
+		t.Fatalf("decrypt failed.err:%v", err)
+	}
+
+	// 篡改地址会导致认证失败
+	tampered := strings.Replace(string(data), acc.Address, "TeyyPLpp9L7QAcxHangtcHTu7HUZ6iydY", 1)
+	if _, err := Decrypt([]byte(tampered), "pass"); err != ErrDecrypt {
+		t.Fatalf("want ErrDecrypt, got %v", err)
+	}
+
+	if _, err := Encrypt(acc, "", nil); err != ErrEmptyPass {
+		t.Fatalf("want ErrEmptyPass, got %v", err)
+	}
+	if _, err := Encrypt(acc, "pass", &Options{KDF: "md5"}); err != ErrKDF {
+		t.Fatalf("want ErrKDF, got %v", err)
+	}
+}
+
+func TestImportMismatch(t *testing.T) {
+	acc, _ := auth.NewXchainEcdsaAccount(auth.MnemStrgthWeak, auth.MnemLangEN)
+	other, _ := auth.NewXchainEcdsaAccount(auth.MnemStrgthWeak, auth.MnemLangEN)
+	ks := newTestStore(t, &testOptions)
+
+	wrong := *acc
+	wrong.Address = other.Address
+	if _, err := ks.Import(&wrong, "pass"); err != ErrAccountInvalid {
+		t.Fatalf("want ErrAccountInvalid, got %v", err)
+	}
+	if ks.Has(other.Address) {
+		t.Fatal("mismatched account should not be stored")
+	}
+}
+
+func TestKDFLimits(t *testing.T) {
+	for _, opts := range []Options{
+		{KDF: KDFScrypt, ScryptN: 1 << 21, ScryptR: 8, ScryptP: 1},
+		{KDF: KDFScrypt, ScryptN: 1 << 10, ScryptR: 8, ScryptP: 16},
+		{KDF: KDFScrypt, ScryptN: 1 << 20, ScryptR: 16, ScryptP: 1},
+		{KDF: KDFPBKDF2, PBKDF2Iter: maxPBKDF2Itr + 1},
+	} {
+		p := kdfParams{N: opts.ScryptN, R: opts.ScryptR, P: opts.ScryptP, C: opts.PBKDF2Iter,
+			Prf: "hmac-sha256", DKLen: keyLen, Salt: "00"}
+		if _, err := deriveKey(opts.KDF, &p, "pass"); err != ErrKDF {
+			t.Fatalf("want ErrKDF for %+v, got %v", opts, err)
+		}
+	}
+
+	// 篡改文件中的参数
+	acc, _ := auth.NewXchainEcdsaAccount(auth.MnemStrgthWeak, auth.MnemLangEN)
+	data, _ := Encrypt(acc, "pass", &testOptions)
+	tampered := strings.Replace(string(data), `"n": 1024`, `"n": 1073741824`, 1)
+	if _, err := Decrypt([]byte(tampered), "pass"); err != ErrKDF {
+		t.Fatalf("want ErrKDF, got %v", err)
+	}
+}
+
+func TestImportLegacyGm(t *testing.T) {
+	acc, _ := auth.NewXchainGmAccount(auth.MnemStrgthWeak, auth.MnemLangEN)
+	acc.CryptoType = 0
+	ks := newTestStore(t, &testOptions)
+	if _, err := ks.Import(acc, "pass"); err != nil {
+		t.Fatalf("import legacy gm account failed.err:%v", err)
+	}
+	loaded, err := ks.Load(acc.Address, "pass")
+	if err != nil {
+		t.Fatalf("load failed.err:%v", err)
+	}
+	if _, err := loaded.Sign([]byte("msg")); err != nil {
+		t.Fatalf("sign failed.err:%v", err)
+	}
+}
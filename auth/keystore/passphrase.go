@@ -0,0 +1,33 @@
+package keystore
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// 口令环境变量，命令行工具共用
+const EnvPassphrase = "XASSET_KEYSTORE_PASSPHRASE"
+
+// ReadPassphrase 读取口令，优先级：口令文件 > 环境变量 > 标准输入
+// 从标准输入读取时先向标准错误输出prompt，去掉行尾换行
+func ReadPassphrase(passFile, prompt string) (string, error) {
+	if passFile != "" {
+		data, err := ioutil.ReadFile(passFile)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+	if pass := os.Getenv(EnvPassphrase); pass != "" {
+		return pass, nil
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
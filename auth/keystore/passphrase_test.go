@@ -0,0 +1,38 @@
+package keystore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadPassphrase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore-pass")
+	if err != nil {
+		t.Fatalf("create temp dir failed.err:%v", err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "pass.txt")
+	ioutil.WriteFile(file, []byte("from-file\r\n"), 0600)
+
+	old, had := os.LookupEnv(EnvPassphrase)
+	os.Setenv(EnvPassphrase, "from-env")
+	defer func() {
+		if had {
+			os.Setenv(EnvPassphrase, old)
+		} else {
+			os.Unsetenv(EnvPassphrase)
+		}
+	}()
+
+	if pass, err := ReadPassphrase(file, ""); err != nil || pass != "from-file" {
+		t.Fatalf("passfile should take precedence.%s err:%v", pass, err)
+	}
+	if pass, err := ReadPassphrase("", ""); err != nil || pass != "from-env" {
+		t.Fatalf("read env failed.%s err:%v", pass, err)
+	}
+	if _, err := ReadPassphrase(filepath.Join(dir, "missing"), ""); err == nil {
+		t.Fatal("missing passfile should fail")
+	}
+}
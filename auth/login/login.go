@@ -0,0 +1,287 @@
+// Package login 使用xasset账户登录的挑战-应答流程
+//
+// 服务端签发带有域名、应用ID、nonce和过期时间的挑战消息，客户端使用账户私钥
+// 签名后提交，服务端校验签名、公钥与地址匹配关系，并保证每个nonce只能使用一次。
+package login
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	"github.com/xuperchain/xasset-sdk-go/common/nonce"
+)
+
+const (
+	// 默认挑战有效期
+	DefaultTTL = 5 * time.Minute
+	// 默认允许的时钟误差
+	DefaultClockSkew = 30 * time.Second
+
+	headerSuffix = " wants you to sign in with your xasset account:"
+	nonceRandLen = 16
+)
+
+var (
+	ErrDomainInvalid    = errors.New("login domain invalid")
+	ErrAddrInvalid      = errors.New("login address invalid")
+	ErrMessageInvalid   = errors.New("login message invalid")
+	ErrDomainMismatch   = errors.New("login domain mismatch")
+	ErrAppIdMismatch    = errors.New("login app id mismatch")
+	ErrAddrMismatch     = errors.New("login address does not match public key")
+	ErrExpired          = errors.New("login challenge expired")
+	ErrNotYetValid      = errors.New("login challenge not yet valid")
+	ErrNonceInvalid     = errors.New("login nonce invalid or not issued by this server")
+	ErrNonceUsed        = errors.New("login nonce already used")
+	ErrSignInvalid      = errors.New("login signature invalid")
+	ErrProofIncomplete  = errors.New("login proof incomplete")
+	ErrPublicKeyInvalid = errors.New("login public key invalid")
+)
+
+// 登录挑战
+type Challenge struct {
+	Domain string `json:"domain"`
+	Addr   string `json:"addr"`
+	AppId  int64  `json:"app_id"`
+	// 展示给用户的说明，可选，不能包含换行
+	Statement string `json:"statement,omitempty"`
+	Nonce     string `json:"nonce"`
+	IssuedAt  int64  `json:"issued_at"`
+	ExpireAt  int64  `json:"expire_at"`
+}
+
+// Message 待签名的消息原文
+func (t *Challenge) Message() string {
+	var b strings.Builder
+	b.WriteString(t.Domain + headerSuffix + "\n")
+	b.WriteString(t.Addr + "\n\n")
+	if t.Statement != "" {
+		b.WriteString(t.Statement + "\n\n")
+	}
+	fmt.Fprintf(&b, "App ID: %d\n", t.AppId)
+	fmt.Fprintf(&b, "Nonce: %s\n", t.Nonce)
+	fmt.Fprintf(&b, "Issued At: %s\n", formatTime(t.IssuedAt))
+	fmt.Fprintf(&b, "Expiration Time: %s", formatTime(t.ExpireAt))
+	return b.String()
+}
+
+// ParseMessage 从消息原文解析挑战
+func ParseMessage(msg string) (*Challenge, error) {
+	lines := strings.Split(msg, "\n")
+	if len(lines) < 7 || !strings.HasSuffix(lines[0], headerSuffix) || lines[2] != "" {
+		return nil, ErrMessageInvalid
+	}
+	c := &Challenge{
+		Domain: strings.TrimSuffix(lines[0], headerSuffix),
+		Addr:   lines[1],
+	}
+	fields := lines[3:]
+	if len(fields) == 6 {
+		if fields[1] != "" {
+			return nil, ErrMessageInvalid
+		}
+		c.Statement = fields[0]
+		fields = fields[2:]
+	}
+	if len(fields) != 4 {
+		return nil, ErrMessageInvalid
+	}
+
+	var err error
+	values := make([]string, 0, 4)
+	for i, key := range []string{"App ID: ", "Nonce: ", "Issued At: ", "Expiration Time: "} {
+		if !strings.HasPrefix(fields[i], key) {
+			return nil, ErrMessageInvalid
+		}
+		values = append(values, strings.TrimPrefix(fields[i], key))
+	}
+	if c.AppId, err = strconv.ParseInt(values[0], 10, 64); err != nil {
+		return nil, ErrMessageInvalid
+	}
+	c.Nonce = values[1]
+	if c.IssuedAt, err = parseTime(values[2]); err != nil {
+		return nil, ErrMessageInvalid
+	}
+	if c.ExpireAt, err = parseTime(values[3]); err != nil {
+		return nil, ErrMessageInvalid
+	}
+	if c.Domain == "" || c.Addr == "" || c.Nonce == "" {
+		return nil, ErrMessageInvalid
+	}
+	return c, nil
+}
+
+// 客户端提交的登录凭证
+type Proof struct {
+	Message string `json:"message"`
+	Addr    string `json:"addr"`
+	PubKey  string `json:"pkey"`
+	Sign    string `json:"sign"`
+}
+
+// Sign 客户端使用账户对挑战签名
+func Sign(account auth.Signer, c *Challenge) (*Proof, error) {
+	if auth.SignerValid(account) != nil {
+		return nil, ErrProofIncomplete
+	}
+	if c == nil || c.Addr != account.GetAddress() {
+		return nil, ErrAddrInvalid
+	}
+	msg := c.Message()
+	sign, err := account.Sign([]byte(msg))
+	if err != nil {
+		return nil, err
+	}
+	return &Proof{
+		Message: msg,
+		Addr:    account.GetAddress(),
+		PubKey:  account.GetPublicKey(),
+		Sign:    sign,
+	}, nil
+}
+
+// 服务端，签发并校验登录挑战
+// Secret 用于对nonce做HMAC，多实例部署时需配置相同的值，为空时随机生成
+type Verifier struct {
+	Domain    string
+	AppId     int64
+	Statement string
+	TTL       time.Duration
+	ClockSkew time.Duration
+
+	secret []byte
+	store  nonce.Store
+	now    func() time.Time
+}
+
+// NewVerifier store为空时使用进程内存储，多实例部署时需传入共享存储
+func NewVerifier(domain string, appId int64, secret []byte, store nonce.Store) (*Verifier, error) {
+	if domain == "" || strings.ContainsAny(domain, " \n") {
+		return nil, ErrDomainInvalid
+	}
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+	}
+	if store == nil {
+		store = nonce.NewMemStore()
+	}
+	return &Verifier{
+		Domain:    domain,
+		AppId:     appId,
+		TTL:       DefaultTTL,
+		ClockSkew: DefaultClockSkew,
+		secret:    secret,
+		store:     store,
+		now:       time.Now,
+	}, nil
+}
+
+// Issue 为地址签发挑战
+func (t *Verifier) Issue(addr string) (*Challenge, error) {
+	if addr == "" || strings.ContainsAny(addr, " \n") {
+		return nil, ErrAddrInvalid
+	}
+	ttl := t.TTL
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	now := t.now()
+	c := &Challenge{
+		Domain:    t.Domain,
+		Addr:      addr,
+		AppId:     t.AppId,
+		Statement: strings.Replace(t.Statement, "\n", " ", -1),
+		IssuedAt:  now.Unix(),
+		ExpireAt:  now.Add(ttl).Unix(),
+	}
+	randBytes := make([]byte, nonceRandLen)
+	if _, err := rand.Read(randBytes); err != nil {
+		return nil, err
+	}
+	r := hex.EncodeToString(randBytes)
+	c.Nonce = r + "." + t.nonceMac(r, c)
+	return c, nil
+}
+
+// Verify 校验登录凭证，成功后nonce被占用，返回对应的挑战
+func (t *Verifier) Verify(p *Proof) (*Challenge, error) {
+	if p == nil || p.Message == "" || p.Addr == "" || p.PubKey == "" || p.Sign == "" {
+		return nil, ErrProofIncomplete
+	}
+	c, err := ParseMessage(p.Message)
+	if err != nil {
+		return nil, err
+	}
+	if c.Domain != t.Domain {
+		return nil, ErrDomainMismatch
+	}
+	if c.AppId != t.AppId {
+		return nil, ErrAppIdMismatch
+	}
+	if c.Addr != p.Addr {
+		return nil, ErrAddrMismatch
+	}
+
+	now := t.now()
+	if now.Add(-t.ClockSkew).Unix() > c.ExpireAt {
+		return nil, ErrExpired
+	}
+	if now.Add(t.ClockSkew).Unix() < c.IssuedAt {
+		return nil, ErrNotYetValid
+	}
+
+	parts := strings.SplitN(c.Nonce, ".", 2)
+	if len(parts) != 2 || !hmac.Equal([]byte(parts[1]), []byte(t.nonceMac(parts[0], c))) {
+		return nil, ErrNonceInvalid
+	}
+
+	pub, err := auth.GetEcdsaPubKeyByJsStr(p.PubKey)
+	if err != nil {
+		return nil, ErrPublicKeyInvalid
+	}
+	derived, err := auth.GetAddrByPubKey(pub)
+	if err != nil || derived != p.Addr {
+		return nil, ErrAddrMismatch
+	}
+	if ok, _ := auth.VerifyAddrByPubKey(p.Addr, pub); !ok {
+		return nil, ErrAddrMismatch
+	}
+	if ok, _ := auth.XassetVerify(p.PubKey, p.Sign, []byte(p.Message)); !ok {
+		return nil, ErrSignInvalid
+	}
+
+	// 校验全部通过后再占用nonce，避免无效请求消耗合法挑战
+	if !t.store.Use("login:"+c.Nonce, time.Unix(c.ExpireAt, 0).Add(t.ClockSkew)) {
+		return nil, ErrNonceUsed
+	}
+	return c, nil
+}
+
+// nonce绑定挑战的全部字段，防止客户端篡改地址、有效期等内容
+func (t *Verifier) nonceMac(r string, c *Challenge) string {
+	mac := hmac.New(sha256.New, t.secret)
+	fmt.Fprintf(mac, "%s|%s|%s|%d|%d|%d", r, c.Domain, c.Addr, c.AppId, c.IssuedAt, c.ExpireAt)
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
+
+func formatTime(ts int64) string {
+	return time.Unix(ts, 0).UTC().Format(time.RFC3339)
+}
+
+func parseTime(s string) (int64, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Unix(), nil
+}
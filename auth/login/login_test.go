@@ -0,0 +1,124 @@
+package login
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+)
+
+func newTestAccount(t *testing.T) *auth.Account {
+	acc, err := auth.NewXchainEcdsaAccount(auth.MnemStrgthWeak, auth.MnemLangEN)
+	if err != nil {
+		t.Fatalf("create account failed.err:%v", err)
+	}
+	return acc
+}
+
+func TestLogin(t *testing.T) {
+	acc := newTestAccount(t)
+	v, err := NewVerifier("scene.example.com", 1001, []byte("secret"), nil)
+	if err != nil {
+		t.Fatalf("new verifier failed.err:%v", err)
+	}
+	v.Statement = "登录以查看藏品"
+
+	c, err := v.Issue(acc.Address)
+	if err != nil {
+		t.Fatalf("issue failed.err:%v", err)
+	}
+	parsed, err := ParseMessage(c.Message())
+	if err != nil || *parsed != *c {
+		t.Fatalf("parse message error.%+v err:%v", parsed, err)
+	}
+
+	proof, err := Sign(acc, c)
+	if err != nil {
+		t.Fatalf("sign failed.err:%v", err)
+	}
+	got, err := v.Verify(proof)
+	if err != nil || got.Addr != acc.Address {
+		t.Fatalf("verify failed.err:%v", err)
+	}
+
+	// 重放
+	if _, err := v.Verify(proof); err != ErrNonceUsed {
+		t.Fatalf("want ErrNonceUsed, got %v", err)
+	}
+
+	// 其他实例使用相同secret可校验
+	v2, _ := NewVerifier("scene.example.com", 1001, []byte("secret"), nil)
+	c2, _ := v.Issue(acc.Address)
+	proof2, _ := Sign(acc, c2)
+	if _, err := v2.Verify(proof2); err != nil {
+		t.Fatalf("verify with shared secret failed.err:%v", err)
+	}
+}
+
+func TestLoginReject(t *testing.T) {
+	acc, other := newTestAccount(t), newTestAccount(t)
+	v, _ := NewVerifier("scene.example.com", 1001, nil, nil)
+	c, _ := v.Issue(acc.Address)
+	proof, _ := Sign(acc, c)
+
+	// 其他域名
+	v2, _ := NewVerifier("evil.example.com", 1001, nil, nil)
+	if _, err := v2.Verify(proof); err != ErrDomainMismatch {
+		t.Fatalf("want ErrDomainMismatch, got %v", err)
+	}
+
+	// 客户端自造nonce
+	forged := *c
+	forged.Nonce = "00.11"
+	fp, _ := Sign(acc, &forged)
+	if _, err := v.Verify(fp); err != ErrNonceInvalid {
+		t.Fatalf("want ErrNonceInvalid, got %v", err)
+	}
+
+	// 延长有效期
+	forged = *c
+	forged.ExpireAt += 3600
+	fp, _ = Sign(acc, &forged)
+	if _, err := v.Verify(fp); err != ErrNonceInvalid {
+		t.Fatalf("want ErrNonceInvalid, got %v", err)
+	}
+
+	// 使用他人公钥
+	bad := *proof
+	bad.PubKey = other.PublicKey
+	if _, err := v.Verify(&bad); err != ErrAddrMismatch {
+		t.Fatalf("want ErrAddrMismatch, got %v", err)
+	}
+
+	// 篡改消息
+	bad = *proof
+	bad.Message = strings.Replace(proof.Message, "App ID: 1001", "App ID: 1002", 1)
+	if _, err := v.Verify(&bad); err != ErrAppIdMismatch {
+		t.Fatalf("want ErrAppIdMismatch, got %v", err)
+	}
+
+	// 他人签名
+	otherSign, _ := auth.XassetSignECDSA(other.PrivateKey, []byte(proof.Message))
+	bad = *proof
+	bad.Sign = otherSign
+	if _, err := v.Verify(&bad); err != ErrSignInvalid {
+		t.Fatalf("want ErrSignInvalid, got %v", err)
+	}
+
+	// 过期
+	v.now = func() time.Time { return time.Now().Add(time.Hour) }
+	if _, err := v.Verify(proof); err != ErrExpired {
+		t.Fatalf("want ErrExpired, got %v", err)
+	}
+
+	// 无效请求不占用nonce
+	v.now = time.Now
+	if _, err := v.Verify(proof); err != nil {
+		t.Fatalf("verify failed.err:%v", err)
+	}
+
+	if _, err := Sign(other, c); err != ErrAddrInvalid {
+		t.Fatalf("want ErrAddrInvalid, got %v", err)
+	}
+}
@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/xuperchain/crypto/core/hdwallet/rand"
+	"github.com/xuperchain/crypto/core/hdwallet/wordlist"
+	gmrand "github.com/xuperchain/crypto/gm/hdwallet/rand"
+)
+
+var (
+	ErrMnemonicEmpty     = errors.New("mnemonic empty")
+	ErrMnemonicWordCount = errors.New("mnemonic word count invalid")
+	ErrMnemonicWord      = errors.New("mnemonic word not in wordlist")
+	ErrMnemonicLang      = errors.New("mnemonic language unknown or mixed")
+	ErrMnemonicChecksum  = errors.New("mnemonic checksum invalid")
+)
+
+// 助记词单词错误，指出第几个单词有误并给出相近单词
+type MnemonicWordError struct {
+	// 从1开始的位置
+	Pos     int
+	Word    string
+	Suggest []string
+}
+
+func (t *MnemonicWordError) Error() string {
+	msg := fmt.Sprintf("%v. pos:%d word:%s", ErrMnemonicWord, t.Pos, t.Word)
+	if len(t.Suggest) > 0 {
+		msg += " suggest:" + strings.Join(t.Suggest, ",")
+	}
+	return msg
+}
+
+func (t *MnemonicWordError) Unwrap() error {
+	return ErrMnemonicWord
+}
+
+// 校验通过的助记词
+type MnemonicInfo struct {
+	// 规范化后的助记词，单个半角空格分隔
+	Mnemonic string
+	Lang     MnemLang
+	Words    int
+}
+
+// 强度对应的单词数
+func (t MnemStrgth) Words() int {
+	switch t {
+	case MnemStrgthWeak:
+		return 12
+	case MnemStrgthMedium:
+		return 18
+	case MnemStrgthStrong:
+		return 24
+	}
+	return 0
+}
+
+// NormalizeMnemonic 规范化助记词：合并连续空白（含全角空格），
+// 英文转小写，无空格的中文助记词按字拆分
+func NormalizeMnemonic(mnemonic string) string {
+	words := strings.Fields(mnemonic)
+	if len(words) == 1 && isHanWord(words[0]) {
+		words = strings.Split(words[0], "")
+	}
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+	return strings.Join(words, " ")
+}
+
+// ValidateMnemonic 校验助记词，返回规范化结果和语言。
+// strg为0时不限制强度，接受12/15/18/21/24个单词；校验和兼容NIST和国密两种助记词
+func ValidateMnemonic(mnemonic string, strg MnemStrgth) (*MnemonicInfo, error) {
+	norm := NormalizeMnemonic(mnemonic)
+	if norm == "" {
+		return nil, ErrMnemonicEmpty
+	}
+	words := strings.Split(norm, " ")
+	if !wordCountValid(len(words), strg) {
+		return nil, ErrMnemonicWordCount
+	}
+
+	lang, err := detectLang(words)
+	if err != nil {
+		return nil, err
+	}
+
+	// 依赖库的错误信息中包含助记词，这里只返回固定错误
+	_, nistErr := rand.GetEntropyFromMnemonic(norm, int(lang))
+	if nistErr != nil {
+		if _, gmErr := gmrand.GetEntropyFromMnemonic(norm, int(lang)); gmErr != nil {
+			return nil, ErrMnemonicChecksum
+		}
+	}
+
+	return &MnemonicInfo{Mnemonic: norm, Lang: lang, Words: len(words)}, nil
+}
+
+func wordCountValid(n int, strg MnemStrgth) bool {
+	if strg != 0 {
+		return n == strg.Words()
+	}
+	switch n {
+	case 12, 15, 18, 21, 24:
+		return true
+	}
+	return false
+}
+
+// 按首个可识别的单词确定语言，其余单词必须属于同一词表
+func detectLang(words []string) (MnemLang, error) {
+	var lang MnemLang
+	for _, w := range words {
+		if _, ok := wordlist.ReversedEnglishWordMap[w]; ok {
+			lang = MnemLangEN
+			break
+		}
+		if _, ok := wordlist.ReversedSimplifiedChineseWordMap[w]; ok {
+			lang = MnemLangCN
+			break
+		}
+	}
+	if lang == 0 {
+		return 0, ErrMnemonicLang
+	}
+
+	dict, list := wordlist.ReversedEnglishWordMap, wordlist.EnglishWordList
+	if lang == MnemLangCN {
+		dict, list = wordlist.ReversedSimplifiedChineseWordMap, nil
+	}
+	for i, w := range words {
+		if _, ok := dict[w]; !ok {
+			return 0, &MnemonicWordError{Pos: i + 1, Word: w, Suggest: suggestWords(w, list)}
+		}
+	}
+	return lang, nil
+}
+
+// 编辑距离不超过2的候选词，最多3个；中文单字不提供建议
+func suggestWords(word string, list []string) []string {
+	type cand struct {
+		word string
+		dist int
+	}
+	var cands []cand
+	for _, w := range list {
+		if d := editDistance(word, w); d <= 2 {
+			cands = append(cands, cand{w, d})
+		}
+	}
+	sort.SliceStable(cands, func(i, j int) bool { return cands[i].dist < cands[j].dist })
+	var res []string
+	for i := 0; i < len(cands) && i < 3; i++ {
+		res = append(res, cands[i].word)
+	}
+	return res
+}
+
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+func isHanWord(s string) bool {
+	for _, r := range s {
+		if !unicode.Is(unicode.Han, r) {
+			return false
+		}
+	}
+	return len(s) > 0
+}
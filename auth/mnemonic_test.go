@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xuperchain/crypto/core/hdwallet/wordlist"
+)
+
+func TestValidateMnemonic(t *testing.T) {
+	en, _ := NewXchainEcdsaAccount(MnemStrgthWeak, MnemLangEN)
+	cn, _ := NewXchainEcdsaAccount(MnemStrgthMedium, MnemLangCN)
+	gm, _ := NewXchainGmAccount(MnemStrgthStrong, MnemLangEN)
+
+	info, err := ValidateMnemonic(en.Mnemonic, MnemStrgthWeak)
+	if err != nil || info.Lang != MnemLangEN || info.Words != 12 {
+		t.Fatalf("validate en failed.err:%v", err)
+	}
+	if _, err := ValidateMnemonic(gm.Mnemonic, 0); err != nil {
+		t.Fatalf("validate gm failed.err:%v", err)
+	}
+
+	// 全角空格、大小写、多余空白
+	messy := "  " + strings.ToUpper(strings.Replace(en.Mnemonic, " ", "　 ", 3)) + "\n"
+	info, err = ValidateMnemonic(messy, 0)
+	if err != nil || info.Mnemonic != en.Mnemonic {
+		t.Fatalf("normalize en failed.%q err:%v", info, err)
+	}
+	// 中文助记词不带空格
+	info, err = ValidateMnemonic(strings.Replace(cn.Mnemonic, " ", "", -1), MnemStrgthMedium)
+	if err != nil || info.Mnemonic != cn.Mnemonic || info.Lang != MnemLangCN {
+		t.Fatalf("normalize cn failed.err:%v", err)
+	}
+	if InferLanguage(" "+cn.Mnemonic) != int(MnemLangCN) {
+		t.Fatalf("infer language failed")
+	}
+
+	if _, err := ValidateMnemonic(en.Mnemonic, MnemStrgthStrong); err != ErrMnemonicWordCount {
+		t.Fatalf("want ErrMnemonicWordCount, got %v", err)
+	}
+	if _, err := ValidateMnemonic("　 ", 0); err != ErrMnemonicEmpty {
+		t.Fatalf("want ErrMnemonicEmpty, got %v", err)
+	}
+
+	// 拼写错误给出位置和建议
+	words := strings.Fields(en.Mnemonic)
+	orig := words[4]
+	words[4] = orig + "zz"
+	_, err = ValidateMnemonic(strings.Join(words, " "), 0)
+	var we *MnemonicWordError
+	if !errors.As(err, &we) || !errors.Is(err, ErrMnemonicWord) || we.Pos != 5 {
+		t.Fatalf("want MnemonicWordError at 5, got %v", err)
+	}
+	if we.Word != orig+"zz" {
+		t.Fatalf("wrong word error.%s", we.Word)
+	}
+	if sg := suggestWords("abandn", wordlist.EnglishWordList); len(sg) == 0 || sg[0] != "abandon" {
+		t.Fatalf("suggest error.%v", sg)
+	}
+
+	// 交换两个不同单词后校验和大概率失败，直到找到一组失败的交换
+	words = strings.Fields(en.Mnemonic)
+	failed := false
+	for i := 1; i < len(words) && !failed; i++ {
+		if words[0] == words[i] {
+			continue
+		}
+		sw := append([]string(nil), words...)
+		sw[0], sw[i] = sw[i], sw[0]
+		_, err := ValidateMnemonic(strings.Join(sw, " "), 0)
+		failed = err == ErrMnemonicChecksum
+	}
+	if !failed {
+		t.Fatalf("checksum should fail for swapped words")
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	cases := []struct {
+		a, b string
+		d    int
+	}{
+		{"abandon", "abandon", 0},
+		{"abandn", "abandon", 1},
+		{"abcde", "abdce", 2},
+		{"", "abc", 3},
+	}
+	for _, c := range cases {
+		if d := editDistance(c.a, c.b); d != c.d {
+			t.Fatalf("distance %s %s want %d got %d", c.a, c.b, c.d, d)
+		}
+	}
+}
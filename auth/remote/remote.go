@@ -0,0 +1,231 @@
+// Package remote 远程签名
+//
+// 签名服务持有私钥，通过Unix socket或本机回环HTTP对外提供签名接口，
+// Signer 实现了 auth.Signer，业务进程使用它签名而无需加载私钥。
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	SchemeUnix = "unix"
+	SchemeHttp = "http"
+
+	PathAccount = "/v1/account"
+	PathSign    = "/v1/sign"
+
+	// 单次签名消息最大长度
+	MaxMsgSize = 64 << 10
+	// 默认请求超时
+	DefaultTimeout = 5 * time.Second
+)
+
+var (
+	ErrEndpoint     = errors.New("endpoint must be unix:///path or http://loopback:port")
+	ErrNotLoopback  = errors.New("http endpoint must listen on loopback")
+	ErrUnauthorized = errors.New("client unauthorized")
+	ErrForbidden    = errors.New("address not allowed for client")
+	ErrNoAccount    = errors.New("account not found")
+	ErrMsgTooLarge  = errors.New("sign msg too large")
+	ErrAddrMismatch = errors.New("remote signer address mismatch")
+)
+
+type AccountResp struct {
+	Address   string `json:"address"`
+	PublicKey string `json:"public_key"`
+}
+
+type SignReq struct {
+	Address string `json:"address"`
+	Msg     []byte `json:"msg"`
+}
+
+type SignResp struct {
+	Sign string `json:"sign"`
+}
+
+type errorResp struct {
+	Error string `json:"error"`
+}
+
+// 解析后的服务地址
+type Endpoint struct {
+	Scheme string
+	// unix为socket路径，http为host:port
+	Addr string
+}
+
+// ParseEndpoint 解析 unix:///path/to/sock 或 http://127.0.0.1:port
+func ParseEndpoint(endpoint string) (*Endpoint, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, ErrEndpoint
+	}
+	switch u.Scheme {
+	case SchemeUnix:
+		path := u.Path
+		if u.Host != "" {
+			path = u.Host + path
+		}
+		if path == "" {
+			return nil, ErrEndpoint
+		}
+		return &Endpoint{Scheme: SchemeUnix, Addr: path}, nil
+	case SchemeHttp:
+		host, _, err := net.SplitHostPort(u.Host)
+		if err != nil {
+			return nil, ErrEndpoint
+		}
+		if !isLoopback(host) {
+			return nil, ErrNotLoopback
+		}
+		return &Endpoint{Scheme: SchemeHttp, Addr: u.Host}, nil
+	}
+	return nil, ErrEndpoint
+}
+
+// Listen 监听服务地址，unix socket文件权限为0600
+func Listen(endpoint string) (net.Listener, error) {
+	ep, err := ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if ep.Scheme == SchemeHttp {
+		return net.Listen("tcp", ep.Addr)
+	}
+
+	// 清理上次异常退出残留的socket文件
+	if fi, err := os.Lstat(ep.Addr); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		os.Remove(ep.Addr)
+	}
+	l, err := net.Listen("unix", ep.Addr)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(ep.Addr, 0600); err != nil {
+		l.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// 远程签名者，实现了 auth.Signer
+type Signer struct {
+	addr   string
+	pubKey string
+	token  string
+	base   string
+	client *http.Client
+}
+
+// NewSigner 连接签名服务并获取地址对应的公钥，token为服务端为该客户端分配的令牌
+func NewSigner(endpoint, token, addr string) (*Signer, error) {
+	ep, err := ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	t := &Signer{addr: addr, token: token}
+	transport := &http.Transport{DisableKeepAlives: false}
+	if ep.Scheme == SchemeUnix {
+		sock := ep.Addr
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", sock)
+		}
+		t.base = "http://" + SchemeUnix
+	} else {
+		t.base = "http://" + ep.Addr
+	}
+	t.client = &http.Client{Transport: transport, Timeout: DefaultTimeout}
+
+	var resp AccountResp
+	if err := t.do(http.MethodGet, PathAccount+"?address="+url.QueryEscape(addr), nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Address != addr {
+		return nil, ErrAddrMismatch
+	}
+	t.pubKey = resp.PublicKey
+	return t, nil
+}
+
+func (t *Signer) GetAddress() string {
+	return t.addr
+}
+
+func (t *Signer) GetPublicKey() string {
+	return t.pubKey
+}
+
+func (t *Signer) Sign(msg []byte) (string, error) {
+	if len(msg) > MaxMsgSize {
+		return "", ErrMsgTooLarge
+	}
+	var resp SignResp
+	if err := t.do(http.MethodPost, PathSign, &SignReq{Address: t.addr, Msg: msg}, &resp); err != nil {
+		return "", err
+	}
+	return resp.Sign, nil
+}
+
+func (t *Signer) do(method, path string, in, out interface{}) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, t.base+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+t.token)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e errorResp
+		json.Unmarshal(data, &e)
+		return statusError(resp.StatusCode, e.Error)
+	}
+	return json.Unmarshal(data, out)
+}
+
+// 将服务端错误还原为包内错误变量，便于调用方判断
+func statusError(code int, msg string) error {
+	for _, err := range []error{ErrUnauthorized, ErrForbidden, ErrNoAccount, ErrMsgTooLarge} {
+		if msg == err.Error() {
+			return err
+		}
+	}
+	return fmt.Errorf("remote sign failed.status:%d err:%s", code, strings.TrimSpace(msg))
+}
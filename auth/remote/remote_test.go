@@ -0,0 +1,181 @@
+package remote
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+)
+
+// 并发安全的审计日志缓冲
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (t *syncBuffer) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.buf.Write(p)
+}
+
+func (t *syncBuffer) records(tb testing.TB) []*AuditRecord {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var recs []*AuditRecord
+	sc := bufio.NewScanner(bytes.NewReader(t.buf.Bytes()))
+	for sc.Scan() {
+		var rec AuditRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			tb.Fatalf("audit line invalid.err:%v", err)
+		}
+		recs = append(recs, &rec)
+	}
+	return recs
+}
+
+func newTestServer(t *testing.T) (*Server, *auth.Account, *auth.Account, *syncBuffer) {
+	creator, _ := auth.NewXchainEcdsaAccount(auth.MnemStrgthWeak, auth.MnemLangEN)
+	other, _ := auth.NewXchainEcdsaAccount(auth.MnemStrgthWeak, auth.MnemLangEN)
+	audit := &syncBuffer{}
+	srv, err := NewServer([]auth.Signer{creator, other}, []*Client{
+		{Name: "api", Token: "api-token", Addrs: []string{creator.Address}},
+		{Name: "ops", Token: "ops-token", Addrs: []string{AnyAddr}},
+	}, audit)
+	if err != nil {
+		t.Fatalf("new server failed.err:%v", err)
+	}
+	return srv, creator, other, audit
+}
+
+func TestUnixSigner(t *testing.T) {
+	srv, creator, other, audit := newTestServer(t)
+	dir, err := ioutil.TempDir("", "signd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	endpoint := "unix://" + filepath.Join(dir, "signd.sock")
+	l, err := Listen(endpoint)
+	if err != nil {
+		t.Fatalf("listen failed.err:%v", err)
+	}
+	go http.Serve(l, srv)
+	defer l.Close()
+
+	if fi, _ := os.Stat(filepath.Join(dir, "signd.sock")); fi.Mode().Perm() != 0600 {
+		t.Fatalf("socket perm error.%v", fi.Mode())
+	}
+
+	s, err := NewSigner(endpoint, "api-token", creator.Address)
+	if err != nil {
+		t.Fatalf("new signer failed.err:%v", err)
+	}
+	if s.GetPublicKey() != creator.PublicKey {
+		t.Fatal("public key mismatch")
+	}
+	var _ auth.Signer = s
+
+	msg := []byte("100200")
+	sign, err := s.Sign(msg)
+	if err != nil {
+		t.Fatalf("sign failed.err:%v", err)
+	}
+	if ok, _ := auth.XassetVerifyECDSA(creator.PublicKey, sign, msg); !ok {
+		t.Fatal("remote sign should verify")
+	}
+
+	if _, err := NewSigner(endpoint, "api-token", other.Address); err != ErrForbidden {
+		t.Fatalf("want ErrForbidden, got %v", err)
+	}
+	if _, err := NewSigner(endpoint, "bad-token", creator.Address); err != ErrUnauthorized {
+		t.Fatalf("want ErrUnauthorized, got %v", err)
+	}
+	if _, err := NewSigner(endpoint, "ops-token", "unknown"); err != ErrNoAccount {
+		t.Fatalf("want ErrNoAccount, got %v", err)
+	}
+	if _, err := s.Sign(make([]byte, MaxMsgSize+1)); err != ErrMsgTooLarge {
+		t.Fatalf("want ErrMsgTooLarge, got %v", err)
+	}
+
+	// 令牌对应地址被改走后签名被拒绝且留有审计
+	s.addr = other.Address
+	if _, err := s.Sign(msg); err != ErrForbidden {
+		t.Fatalf("want ErrForbidden, got %v", err)
+	}
+
+	recs := audit.records(t)
+	if len(recs) != 2 {
+		t.Fatalf("audit record count error.%d", len(recs))
+	}
+	if recs[0].Client != "api" || recs[0].Address != creator.Address || recs[0].Sign != sign || recs[0].Msg != "100200" {
+		t.Fatalf("audit record error.%+v", recs[0])
+	}
+	if recs[1].Error != ErrForbidden.Error() || recs[1].Sign != "" {
+		t.Fatalf("denied audit record error.%+v", recs[1])
+	}
+}
+
+func TestHttpSigner(t *testing.T) {
+	srv, _, other, audit := newTestServer(t)
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	s, err := NewSigner(ts.URL, "ops-token", other.Address)
+	if err != nil {
+		t.Fatalf("new signer failed.err:%v", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.Sign([]byte("msg")); err != nil {
+				t.Errorf("sign failed.err:%v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := len(audit.records(t)); n != 8 {
+		t.Fatalf("audit record count error.%d", n)
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	for _, c := range []struct {
+		endpoint string
+		err      error
+	}{
+		{"unix:///tmp/signd.sock", nil},
+		{"http://127.0.0.1:8800", nil},
+		{"http://localhost:8800", nil},
+		{"http://[::1]:8800", nil},
+		{"http://0.0.0.0:8800", ErrNotLoopback},
+		{"http://10.0.0.1:8800", ErrNotLoopback},
+		{"https://127.0.0.1:8800", ErrEndpoint},
+		{"unix://", ErrEndpoint},
+	} {
+		if _, err := ParseEndpoint(c.endpoint); err != c.err {
+			t.Errorf("%s want %v, got %v", c.endpoint, c.err, err)
+		}
+	}
+
+	if remoteAllowed("10.0.0.1:5555") || !remoteAllowed("127.0.0.1:5555") || !remoteAllowed("@") {
+		t.Fatal("remote check error")
+	}
+	if _, err := NewServer(nil, []*Client{{Token: "a"}, {Token: "a"}}, nil); err != ErrClientToken {
+		t.Fatalf("want ErrClientToken, got %v", err)
+	}
+	if !strings.HasPrefix(statusError(500, "boom").Error(), "remote sign failed") {
+		t.Fatal("status error format")
+	}
+}
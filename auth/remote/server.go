@@ -0,0 +1,220 @@
+package remote
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+)
+
+// 允许所有地址
+const AnyAddr = "*"
+
+var (
+	ErrClientToken = errors.New("client token empty or duplicated")
+	ErrNoSigner    = errors.New("signer unset")
+	ErrBadRequest  = errors.New("bad request")
+)
+
+// 白名单中的客户端，只能使用Addrs中的账户签名
+type Client struct {
+	Name  string   `json:"name"`
+	Token string   `json:"token"`
+	Addrs []string `json:"addrs"`
+}
+
+func (t *Client) allow(addr string) bool {
+	for _, a := range t.Addrs {
+		if a == AnyAddr || a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// 审计日志，每次签名请求（包括被拒绝的）记录一行json
+type AuditRecord struct {
+	Time      string `json:"time"`
+	Client    string `json:"client"`
+	Remote    string `json:"remote"`
+	Address   string `json:"address"`
+	Msg       string `json:"msg"`
+	MsgSha256 string `json:"msg_sha256"`
+	Sign      string `json:"sign,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// 签名服务，实现了 http.Handler
+type Server struct {
+	signers map[string]auth.Signer
+	clients []*Client
+
+	mu    sync.Mutex
+	audit *json.Encoder
+	now   func() time.Time
+}
+
+// NewServer audit为空时不记录审计日志
+func NewServer(signers []auth.Signer, clients []*Client, audit io.Writer) (*Server, error) {
+	t := &Server{
+		signers: make(map[string]auth.Signer, len(signers)),
+		now:     time.Now,
+	}
+	for _, s := range signers {
+		if err := auth.SignerValid(s); err != nil {
+			return nil, ErrNoSigner
+		}
+		t.signers[s.GetAddress()] = s
+	}
+	seen := make(map[string]bool, len(clients))
+	for _, c := range clients {
+		if c == nil || c.Token == "" || seen[c.Token] {
+			return nil, ErrClientToken
+		}
+		seen[c.Token] = true
+	}
+	t.clients = clients
+	if audit != nil {
+		t.audit = json.NewEncoder(audit)
+	}
+	return t, nil
+}
+
+func (t *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !remoteAllowed(r.RemoteAddr) {
+		writeError(w, http.StatusForbidden, ErrNotLoopback)
+		return
+	}
+	client := t.authClient(r)
+	if client == nil {
+		writeError(w, http.StatusUnauthorized, ErrUnauthorized)
+		return
+	}
+
+	switch {
+	case r.URL.Path == PathAccount && r.Method == http.MethodGet:
+		t.serveAccount(w, r, client)
+	case r.URL.Path == PathSign && r.Method == http.MethodPost:
+		t.serveSign(w, r, client)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (t *Server) serveAccount(w http.ResponseWriter, r *http.Request, client *Client) {
+	addr := r.URL.Query().Get("address")
+	if !client.allow(addr) {
+		writeError(w, http.StatusForbidden, ErrForbidden)
+		return
+	}
+	s, ok := t.signers[addr]
+	if !ok {
+		writeError(w, http.StatusNotFound, ErrNoAccount)
+		return
+	}
+	writeJson(w, &AccountResp{Address: addr, PublicKey: s.GetPublicKey()})
+}
+
+func (t *Server) serveSign(w http.ResponseWriter, r *http.Request, client *Client) {
+	var req SignReq
+	// base64编码后长度约为4/3，额外预留json字段空间
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, MaxMsgSize*2))
+	if err != nil || json.Unmarshal(data, &req) != nil {
+		writeError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	rec := &AuditRecord{
+		Time:    t.now().UTC().Format(time.RFC3339Nano),
+		Client:  client.Name,
+		Remote:  r.RemoteAddr,
+		Address: req.Address,
+		Msg:     string(req.Msg),
+	}
+	sum := sha256.Sum256(req.Msg)
+	rec.MsgSha256 = hex.EncodeToString(sum[:])
+
+	code, err := t.sign(client, &req, rec)
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	t.writeAudit(rec)
+	if err != nil {
+		writeError(w, code, err)
+		return
+	}
+	writeJson(w, &SignResp{Sign: rec.Sign})
+}
+
+func (t *Server) sign(client *Client, req *SignReq, rec *AuditRecord) (int, error) {
+	if len(req.Msg) > MaxMsgSize {
+		return http.StatusBadRequest, ErrMsgTooLarge
+	}
+	if !client.allow(req.Address) {
+		return http.StatusForbidden, ErrForbidden
+	}
+	s, ok := t.signers[req.Address]
+	if !ok {
+		return http.StatusNotFound, ErrNoAccount
+	}
+	sign, err := s.Sign(req.Msg)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	rec.Sign = sign
+	return http.StatusOK, nil
+}
+
+// 按令牌查找客户端，逐个做常量时间比较
+func (t *Server) authClient(r *http.Request) *Client {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		return nil
+	}
+	var found *Client
+	for _, c := range t.clients {
+		if subtle.ConstantTimeCompare([]byte(c.Token), []byte(token)) == 1 {
+			found = c
+		}
+	}
+	return found
+}
+
+func (t *Server) writeAudit(rec *AuditRecord) {
+	if t.audit == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.audit.Encode(rec)
+}
+
+// unix socket的对端地址不是host:port，只限制tcp连接来自回环地址
+func remoteAllowed(remote string) bool {
+	host, _, err := net.SplitHostPort(remote)
+	if err != nil {
+		return true
+	}
+	return isLoopback(host)
+}
+
+func writeJson(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(&errorResp{Error: err.Error()})
+}
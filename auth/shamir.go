@@ -0,0 +1,234 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 助记词分片备份，基于GF(256)上的Shamir秘密共享
+//
+// 分片编码为 xs1-<组id>-<门限>-<总数>-<序号>-<数据>-<校验>，
+// 组id用于识别同一次拆分的分片，校验为前面内容SHA256的前4字节，
+// 抄写错误的分片在合并前即可发现。
+
+const (
+	sharePrefix = "xs1"
+	// 最多255个分片，序号取1~255
+	MaxShares = 255
+)
+
+var (
+	ErrShareParam      = errors.New("share threshold or count invalid")
+	ErrShareFormat     = errors.New("share format invalid")
+	ErrShareChecksum   = errors.New("share checksum mismatch")
+	ErrShareMismatch   = errors.New("shares not from the same split")
+	ErrShareDuplicated = errors.New("share duplicated")
+	ErrShareNotEnough  = errors.New("shares not enough for threshold")
+)
+
+// 解析后的分片
+type Share struct {
+	// 组id，同一次拆分的分片相同
+	Group string
+	// 门限，至少需要的分片数
+	Threshold int
+	// 分片总数
+	Total int
+	// 分片序号，即多项式的x值
+	Index int
+	Data  []byte
+}
+
+func (t *Share) body() string {
+	return fmt.Sprintf("%s-%s-%d-%d-%d-%s", sharePrefix, t.Group, t.Threshold, t.Total, t.Index, hex.EncodeToString(t.Data))
+}
+
+// String 编码分片
+func (t *Share) String() string {
+	body := t.body()
+	sum := sha256.Sum256([]byte(body))
+	return body + "-" + hex.EncodeToString(sum[:4])
+}
+
+// ParseShare 解析并校验分片
+func ParseShare(s string) (*Share, error) {
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) != 7 || parts[0] != sharePrefix {
+		return nil, ErrShareFormat
+	}
+	t := &Share{Group: parts[1]}
+	var err error
+	if t.Threshold, err = strconv.Atoi(parts[2]); err != nil {
+		return nil, ErrShareFormat
+	}
+	if t.Total, err = strconv.Atoi(parts[3]); err != nil {
+		return nil, ErrShareFormat
+	}
+	if t.Index, err = strconv.Atoi(parts[4]); err != nil {
+		return nil, ErrShareFormat
+	}
+	if t.Data, err = hex.DecodeString(parts[5]); err != nil || len(t.Data) == 0 {
+		return nil, ErrShareFormat
+	}
+	if t.Threshold < 2 || t.Threshold > t.Total || t.Total > MaxShares || t.Index < 1 || t.Index > t.Total {
+		return nil, ErrShareFormat
+	}
+
+	sum := sha256.Sum256([]byte(t.body()))
+	if parts[6] != hex.EncodeToString(sum[:4]) {
+		return nil, ErrShareChecksum
+	}
+	return t, nil
+}
+
+// SplitMnemonic 将助记词拆分为n个分片，任意k个即可恢复
+func SplitMnemonic(mnemonic string, n, k int) ([]string, error) {
+	if k < 2 || k > n || n > MaxShares {
+		return nil, ErrShareParam
+	}
+	secret := []byte(strings.TrimSpace(mnemonic))
+	if len(secret) == 0 {
+		return nil, ErrShareParam
+	}
+
+	group := make([]byte, 4)
+	if _, err := rand.Read(group); err != nil {
+		return nil, err
+	}
+	shares := make([]*Share, n)
+	for i := range shares {
+		shares[i] = &Share{
+			Group:     hex.EncodeToString(group),
+			Threshold: k,
+			Total:     n,
+			Index:     i + 1,
+			Data:      make([]byte, len(secret)),
+		}
+	}
+
+	// 每个字节独立构造k-1次随机多项式，常数项为秘密
+	coef := make([]byte, k)
+	for pos, b := range secret {
+		if _, err := rand.Read(coef[1:]); err != nil {
+			return nil, err
+		}
+		coef[0] = b
+		for _, s := range shares {
+			s.Data[pos] = gfEval(coef, byte(s.Index))
+		}
+	}
+
+	res := make([]string, n)
+	for i, s := range shares {
+		res[i] = s.String()
+	}
+	return res, nil
+}
+
+// CombineShares 合并分片得到助记词，分片数须不少于门限
+func CombineShares(shares []string) (string, error) {
+	if len(shares) == 0 {
+		return "", ErrShareNotEnough
+	}
+	parsed := make([]*Share, 0, len(shares))
+	seen := make(map[int]bool, len(shares))
+	for _, str := range shares {
+		s, err := ParseShare(str)
+		if err != nil {
+			return "", err
+		}
+		if len(parsed) > 0 {
+			f := parsed[0]
+			if s.Group != f.Group || s.Threshold != f.Threshold || s.Total != f.Total || len(s.Data) != len(f.Data) {
+				return "", ErrShareMismatch
+			}
+		}
+		if seen[s.Index] {
+			return "", ErrShareDuplicated
+		}
+		seen[s.Index] = true
+		parsed = append(parsed, s)
+	}
+	if len(parsed) < parsed[0].Threshold {
+		return "", ErrShareNotEnough
+	}
+	parsed = parsed[:parsed[0].Threshold]
+
+	// 拉格朗日插值求x=0处的值
+	secret := make([]byte, len(parsed[0].Data))
+	for i, si := range parsed {
+		xi := byte(si.Index)
+		// li(0) = ∏ xj/(xj^xi)，GF(256)中加减均为异或
+		li := byte(1)
+		for j, sj := range parsed {
+			if i == j {
+				continue
+			}
+			xj := byte(sj.Index)
+			li = gfMul(li, gfDiv(xj, xj^xi))
+		}
+		for pos := range secret {
+			secret[pos] ^= gfMul(si.Data[pos], li)
+		}
+	}
+	return string(secret), nil
+}
+
+// RetrieveAccountByShares 合并分片并恢复账户，助记词校验失败时返回错误
+func RetrieveAccountByShares(ct CryptoType, shares []string, language int) (*Account, error) {
+	mnemonic, err := CombineShares(shares)
+	if err != nil {
+		return nil, err
+	}
+	return RetrieveAccount(ct, mnemonic, language)
+}
+
+// GF(256)运算，既约多项式x^8+x^4+x^3+x+1，生成元3
+var gfExp, gfLog = gfTables()
+
+func gfTables() (exp [510]byte, log [256]byte) {
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		exp[i] = x
+		log[x] = byte(i)
+		// x *= 3
+		hi := x & 0x80
+		x2 := x << 1
+		if hi != 0 {
+			x2 ^= 0x1b
+		}
+		x ^= x2
+	}
+	for i := 255; i < len(exp); i++ {
+		exp[i] = exp[i-255]
+	}
+	return
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+255-int(gfLog[b])]
+}
+
+// 霍纳法则计算多项式在x处的值
+func gfEval(coef []byte, x byte) byte {
+	var y byte
+	for i := len(coef) - 1; i >= 0; i-- {
+		y = gfMul(y, x) ^ coef[i]
+	}
+	return y
+}
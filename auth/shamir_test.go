@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitMnemonic(t *testing.T) {
+	acc, err := NewXchainEcdsaAccount(MnemStrgthMedium, MnemLangCN)
+	if err != nil {
+		t.Fatalf("create account failed.err:%v", err)
+	}
+	shares, err := SplitMnemonic(acc.Mnemonic, 5, 3)
+	if err != nil || len(shares) != 5 {
+		t.Fatalf("split failed.err:%v", err)
+	}
+
+	// 任意3个分片均可恢复
+	for _, idx := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3, 4}} {
+		var picked []string
+		for _, i := range idx {
+			picked = append(picked, shares[i])
+		}
+		rc, err := RetrieveAccountByShares(CryptoTypeNist, picked, int(MnemLangCN))
+		if err != nil || rc.Address != acc.Address {
+			t.Fatalf("combine %v failed.err:%v", idx, err)
+		}
+	}
+
+	if _, err := CombineShares(shares[:2]); err != ErrShareNotEnough {
+		t.Fatalf("want ErrShareNotEnough, got %v", err)
+	}
+	if _, err := CombineShares([]string{shares[0], shares[0], shares[1]}); err != ErrShareDuplicated {
+		t.Fatalf("want ErrShareDuplicated, got %v", err)
+	}
+	other, _ := SplitMnemonic(acc.Mnemonic, 5, 3)
+	if _, err := CombineShares([]string{shares[0], shares[1], other[2]}); err != ErrShareMismatch {
+		t.Fatalf("want ErrShareMismatch, got %v", err)
+	}
+
+	// 抄写错误
+	s := []byte(shares[0])
+	pos := strings.LastIndex(shares[0], "-") - 1
+	if s[pos] == '0' {
+		s[pos] = '1'
+	} else {
+		s[pos] = '0'
+	}
+	if _, err := ParseShare(string(s)); err != ErrShareChecksum {
+		t.Fatalf("want ErrShareChecksum, got %v", err)
+	}
+	if _, err := ParseShare("xs1-bad"); err != ErrShareFormat {
+		t.Fatalf("want ErrShareFormat, got %v", err)
+	}
+}
+
+func TestSplitMnemonicParam(t *testing.T) {
+	for _, c := range [][2]int{{3, 1}, {2, 3}, {256, 2}} {
+		if _, err := SplitMnemonic("a b c", c[0], c[1]); err != ErrShareParam {
+			t.Fatalf("n:%d k:%d want ErrShareParam, got %v", c[0], c[1], err)
+		}
+	}
+	if _, err := SplitMnemonic(" ", 3, 2); err != ErrShareParam {
+		t.Fatalf("want ErrShareParam, got %v", err)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/baidubce/bce-sdk-go/util"
+	"github.com/xuperchain/xasset-sdk-go/common/redact"
 )
 
 var (
@@ -39,7 +40,7 @@ type Credentials struct {
 
 func (t *Credentials) String() string {
 	return fmt.Sprintf("AppId:%d AccessKeyId:%s SecretAccessKey:%s",
-		t.AppId, t.AccessKeyId, t.SecretAccessKey)
+		t.AppId, t.AccessKeyId, redact.Secret(t.SecretAccessKey))
 }
 
 func (t *SignOptions) String() string {
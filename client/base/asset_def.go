@@ -6,6 +6,8 @@ import (
 	"unicode/utf8"
 
 	"github.com/xuperchain/xasset-sdk-go/auth"
+	"github.com/xuperchain/xasset-sdk-go/common/redact"
+	"github.com/xuperchain/xasset-sdk-go/common/transfer"
 )
 
 const (
@@ -94,15 +96,15 @@ type BoxGift struct {
 
 type GrantBoxParam struct {
 	Token       string
-	UAccount    *auth.Account
-	CAccount    *auth.Account
+	UAccount    auth.Signer
+	CAccount    auth.Signer
 	RealAssetId int64
 	BoxAssetId  int64
 	UserId      int64
 }
 
 func (t *GrantBoxParam) Valid() error {
-	if t.Token == "" || t.UAccount == nil || t.CAccount == nil || t.RealAssetId < 1 || t.BoxAssetId < 1 {
+	if t.Token == "" || AccountValid(t.UAccount) != nil || AccountValid(t.CAccount) != nil || t.RealAssetId < 1 || t.BoxAssetId < 1 {
 		return ErrAssetInvalid
 	}
 	return nil
@@ -171,13 +173,13 @@ type ComposeParam struct {
 	Sign     string
 	Token    string
 	AstList  string
-	Account  *auth.Account //composite asset creator
-	UAccount *auth.Account //consume shard owner
+	Account  auth.Signer //composite asset creator
+	UAccount auth.Signer //consume shard owner
 }
 
 func (t *ComposeParam) Valid() error {
 	if t.AssetId < 1 || t.StrgNo <= 0 || t.Nonce < 1 || t.Sign == "" || t.AstList == "" ||
-		t.Account == nil || t.UAccount == nil {
+		AccountValid(t.Account) != nil || AccountValid(t.UAccount) != nil {
 		return ErrAssetInvalid
 	}
 	return nil
@@ -191,7 +193,7 @@ type ComposeResp struct {
 
 // ///// Gen Token /////////
 type GetStokenParam struct {
-	Account *auth.Account `json:"account"`
+	Account auth.Signer `json:"account"`
 }
 
 func (t *GetStokenParam) Valid() error {
@@ -215,6 +217,12 @@ type AccessInfo struct {
 	Expiration   string `json:"expiration"`
 }
 
+func (t AccessInfo) String() string {
+	return fmt.Sprintf("{Bucket:%s EndPoint:%s ObjectPath:%s AK:%s SK:%s SessionToken:%s CreateTime:%s Expiration:%s}",
+		t.Bucket, t.EndPoint, t.ObjectPath, t.AK, redact.Secret(t.SK), redact.Secret(t.SessionToken),
+		t.CreateTime, t.Expiration)
+}
+
 type GetStokenResp struct {
 	BaseResp
 	AccessInfo *AccessInfo `json:"accessInfo"`
@@ -226,13 +234,17 @@ type GetStokenResp struct {
 // FilePath 文件绝对路径
 // DataByte 文件二进制串
 // Property 文件属性。例如图片类型文件，则为图片宽高，格式为 width_height
+// Progress 可选，上传进度回调，可通过 transfer.Group 汇总多个上传的进度
+// Limiter 可选，带宽限制，多个上传共用同一个 Limiter 时限制总带宽
 // 注意：文件路径和二进制串为二选一
 type UploadFileParam struct {
-	Account  *auth.Account `json:"account"`
-	FileName string        `json:"file_name"`
-	FilePath string        `json:"file_path"`
-	DataByte []byte        `json:"data_byte"`
-	Property string        `json:"property"`
+	Account  auth.Signer           `json:"account"`
+	FileName string                `json:"file_name"`
+	FilePath string                `json:"file_path"`
+	DataByte []byte                `json:"data_byte"`
+	Property string                `json:"property"`
+	Progress transfer.ProgressFunc `json:"-"`
+	Limiter  *transfer.Limiter     `json:"-"`
 }
 
 func (t *UploadFileParam) Valid() error {
@@ -300,7 +312,7 @@ type CreateAssetParam struct {
 	Price      int64            `json:"price,omitempty"`
 	Amount     int              `json:"amount"`
 	AssetInfo  *CreateAssetInfo `json:"asset_info"`
-	Account    *auth.Account    `json:"account"`
+	Account    auth.Signer      `json:"account"`
 	UserId     int64            `json:"user_id,omitempty"`
 	FileHash   string           `json:"file_hash,omitempty"`
 	ViewType   int              `json:"view_type"`
@@ -323,6 +335,9 @@ func (t *CreateAssetParam) Valid() error {
 	if err := CreateAssetInfoValid(t.AssetInfo); err != nil {
 		return err
 	}
+	if err := AssetExtValid(t.AssetInfo.AssetCate, t.AssetInfo.AssetExt); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -375,7 +390,7 @@ type AlterAssetParam struct {
 	Amount    int             `json:"amount,omitempty"`
 	FileHash  string          `json:"file_hash"`
 	AssetInfo *AlterAssetInfo `json:"asset_info"`
-	Account   *auth.Account   `json:"account"`
+	Account   auth.Signer     `json:"account"`
 	ViewType  int             `json:"view_type"`
 }
 
@@ -401,9 +416,9 @@ func (t *AlterAssetParam) Valid() error {
 
 // //////// Publish Asset ////////////
 type PublishAssetParam struct {
-	AssetId    int64         `json:"asset_id"`
-	Account    *auth.Account `json:"account"`
-	IsEvidence int           `json:"is_evidence,omitempty"`
+	AssetId    int64       `json:"asset_id"`
+	Account    auth.Signer `json:"account"`
+	IsEvidence int         `json:"is_evidence,omitempty"`
 }
 
 func (t *PublishAssetParam) Valid() error {
@@ -443,40 +458,40 @@ type QueryAssetResp struct {
 }
 
 type QueryAssetMeta struct {
-	AssetId    int64      `json:"asset_id"`
-	GroupId    int64      `json:"group_id"`
-	AssetCate  int        `json:"asset_cate"`
-	Title      string     `json:"title"`
-	Thumb      []ThumbMap `json:"thumb"`
-	ShortDesc  string     `json:"short_desc"`
-	LongDesc   string     `json:"long_desc"`
-	ImgDesc    []string   `json:"img_desc"`
-	AssetUrl   []string   `json:"asset_url"`
-	AssetExt   string     `json:"asset_ext"`
-	Price      int64      `json:"price"`
-	Amount     int        `json:"amount"`
-	Status     int        `json:"status"`
-	CreateAddr string     `json:"create_addr"`
-	Ctime      int64      `json:"ctime"`
-	Mtime      int64      `json:"mtime"`
-	TxId       string     `json:"tx_id"`
-	ProcScript string     `json:"proc_script"`
-	Version    int64      `json:"version"`
-	ViewType   int        `json:"view_type"`
-	AssetParam string     `json:"asset_param"`
-	ExpireTime int64      `json:"expire_time"`
+	AssetId    int64       `json:"asset_id"`
+	GroupId    int64       `json:"group_id"`
+	AssetCate  int         `json:"asset_cate"`
+	Title      string      `json:"title"`
+	Thumb      []ThumbMap  `json:"thumb"`
+	ShortDesc  string      `json:"short_desc"`
+	LongDesc   string      `json:"long_desc"`
+	ImgDesc    []string    `json:"img_desc"`
+	AssetUrl   []string    `json:"asset_url"`
+	AssetExt   string      `json:"asset_ext"`
+	Price      int64       `json:"price"`
+	Amount     int         `json:"amount"`
+	Status     AssetStatus `json:"status"`
+	CreateAddr string      `json:"create_addr"`
+	Ctime      int64       `json:"ctime"`
+	Mtime      int64       `json:"mtime"`
+	TxId       string      `json:"tx_id"`
+	ProcScript string      `json:"proc_script"`
+	Version    int64       `json:"version"`
+	ViewType   int         `json:"view_type"`
+	AssetParam string      `json:"asset_param"`
+	ExpireTime int64       `json:"expire_time"`
 }
 
 // //////// Grant Asset /////////////
 type GrantAssetParam struct {
-	AssetId    int64         `json:"asset_id"`
-	ShardId    int64         `json:"shard_id"`
-	Price      int64         `json:"price,omitempty"`
-	Account    *auth.Account `json:"account"`
-	Addr       string        `json:"addr"`
-	ToAddr     string        `json:"to_addr"`
-	ToUserId   int64         `json:"to_userid,omitempty"`
-	ShardParam string        `json:"shard_param"`
+	AssetId    int64       `json:"asset_id"`
+	ShardId    int64       `json:"shard_id"`
+	Price      int64       `json:"price,omitempty"`
+	Account    auth.Signer `json:"account"`
+	Addr       string      `json:"addr"`
+	ToAddr     string      `json:"to_addr"`
+	ToUserId   int64       `json:"to_userid,omitempty"`
+	ShardParam string      `json:"shard_param"`
 }
 
 func (p *GrantAssetParam) Valid() error {
@@ -536,7 +551,7 @@ type QueryShardMeta struct {
 	ShardId    int64           `json:"shard_id"`
 	Price      int64           `json:"price"`
 	OwnerAddr  string          `json:"owner_addr"`
-	Status     int             `json:"status"`
+	Status     ShardStatus     `json:"status"`
 	TxId       string          `json:"tx_id"`
 	AssetInfo  *ShardAssetInfo `json:"asset_info"`
 	Ctime      int64           `json:"ctime"`
@@ -563,8 +578,9 @@ type ListShardsByAddrParam struct {
 	Page  int    `json:"page"`
 	Limit int    `json:"limit"`
 	// 可选
-	AssetId int64       `json:"asset_id"`
-	Status  interface{} `json:"status"` //using type interface distinguish default nil and 0
+	AssetId int64 `json:"asset_id"`
+	// 为nil时不按状态过滤，可使用 ShardStatusOnChain.Ptr()
+	Status *ShardStatus `json:"status"`
 }
 
 func (t *ListShardsByAddrParam) Valid() error {
@@ -577,6 +593,11 @@ func (t *ListShardsByAddrParam) Valid() error {
 	if err := IdValid(int64(t.Page)); err != nil {
 		return err
 	}
+	if t.Status != nil {
+		if err := t.Status.Valid(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -617,9 +638,9 @@ type ListAssetsByAddrResp struct {
 type ListDiffByAddrParam struct {
 	Addr string `json:"addr"`
 	// 可选参数
-	Limit  int    `json:"limit"`
-	Cursor string `json:"cursor"`
-	OpTyps string `json:"op_types"`
+	Limit  int      `json:"limit"`
+	Cursor string   `json:"cursor"`
+	OpTyps []DiffOp `json:"op_types"`
 }
 
 func (t *ListDiffByAddrParam) Valid() error {
@@ -627,23 +648,13 @@ func (t *ListDiffByAddrParam) Valid() error {
 		return ErrParamInvalid
 	}
 
-	if t.OpTyps == "" {
-		return nil
-	}
-
-	var arr []int
-	err := json.Unmarshal([]byte(t.OpTyps), &arr)
-	if err != nil {
-		return ErrParamInvalid
-	}
-
-	return nil
+	return DiffOpsValid(t.OpTyps)
 }
 
 type ListDiffByAddrNode struct {
 	AssetId int64      `json:"asset_id"`
 	ShardId int64      `json:"shard_id"`
-	Operate int        `json:"operate"`
+	Operate DiffOp     `json:"operate"`
 	Title   string     `json:"title"`
 	Thumb   []ThumbMap `json:"thumb"`
 	Ctime   int64      `json:"ctime"`
@@ -718,13 +729,13 @@ type HoraeAssetObject struct {
 
 // //////// Transfer Asset //////////
 type TransferAssetParam struct {
-	AssetId  int64         `json:"asset_id"`
-	ShardId  int64         `json:"shard_id"`
-	Price    int64         `json:"price,omitempty"`
-	Account  *auth.Account `json:"account"`
-	Addr     string        `json:"addr"`
-	ToAddr   string        `json:"to_addr"`
-	ToUserId int64         `json:"to_userid,omitempty"`
+	AssetId  int64       `json:"asset_id"`
+	ShardId  int64       `json:"shard_id"`
+	Price    int64       `json:"price,omitempty"`
+	Account  auth.Signer `json:"account"`
+	Addr     string      `json:"addr"`
+	ToAddr   string      `json:"to_addr"`
+	ToUserId int64       `json:"to_userid,omitempty"`
 }
 
 func (p *TransferAssetParam) Valid() error {
@@ -754,8 +765,8 @@ func (p *TransferAssetParam) Valid() error {
 
 // //////// Freeze Asset ////////////
 type FreezeAssetParam struct {
-	AssetId int64         `json:"asset_id"`
-	Account *auth.Account `json:"account"`
+	AssetId int64       `json:"asset_id"`
+	Account auth.Signer `json:"account"`
 }
 
 func (t *FreezeAssetParam) Valid() error {
@@ -773,13 +784,13 @@ func (t *FreezeAssetParam) Valid() error {
 
 // //////// Consume Shard ////////////
 type ConsumeShardParam struct {
-	AssetId  int64         `json:"asset_id"`
-	ShardId  int64         `json:"shard_id"`
-	Nonce    int64         `json:"nonce"`
-	UAddr    string        `json:"user_addr"`
-	USign    string        `json:"user_sign"`
-	UPKey    string        `json:"user_pkey"`
-	CAccount *auth.Account `json:"create_account"`
+	AssetId  int64       `json:"asset_id"`
+	ShardId  int64       `json:"shard_id"`
+	Nonce    int64       `json:"nonce"`
+	UAddr    string      `json:"user_addr"`
+	USign    string      `json:"user_sign"`
+	UPKey    string      `json:"user_pkey"`
+	CAccount auth.Signer `json:"create_account"`
 }
 
 func (t *ConsumeShardParam) Valid() error {
@@ -823,14 +834,14 @@ func (t *ListAssetHisParam) Valid() error {
 }
 
 type HistoryMeta struct {
-	AssetId int64  `json:"asset_id"`
-	Type    int    `json:"type"`
-	ShardId int64  `json:"shard_id"`
-	Price   int64  `json:"price"`
-	TxId    string `json:"tx_id"`
-	From    string `json:"from"`
-	To      string `json:"to"`
-	Ctime   int64  `json:"ctime"`
+	AssetId int64       `json:"asset_id"`
+	Type    HistoryType `json:"type"`
+	ShardId int64       `json:"shard_id"`
+	Price   int64       `json:"price"`
+	TxId    string      `json:"tx_id"`
+	From    string      `json:"from"`
+	To      string      `json:"to"`
+	Ctime   int64       `json:"ctime"`
 }
 
 type ListAssetHistoryResp struct {
@@ -888,7 +899,7 @@ type LockOrFreezeShardParam struct {
 	AssetId int64
 	ShardId int64
 	OpType  int
-	Account *auth.Account
+	Account auth.Signer
 }
 
 func (t *LockOrFreezeShardParam) Valid() error {
@@ -978,20 +989,20 @@ type SceneQueryShardResp struct {
 }
 
 type SceneQueryMeta struct {
-	AssetId    int64      `json:"asset_id"`
-	ShardId    int64      `json:"shard_id"`
-	OwnerAddr  string     `json:"owner_addr"`
-	Status     int        `json:"status"`
-	TxId       string     `json:"tx_id"`
-	Ctime      int64      `json:"ctime"`
-	JumpLink   string     `json:"jump_link"`
-	Price      int64      `json:"price"`
-	Title      string     `json:"title"`
-	Thumb      []ThumbMap `json:"thumb"`
-	AssetUrl   []string   `json:"asset_url"`
-	ImgDesc    []string   `json:"img_desc"`
-	ShortDesc  string     `json:"short_desc"`
-	CreateAddr string     `json:"create_addr"`
+	AssetId    int64       `json:"asset_id"`
+	ShardId    int64       `json:"shard_id"`
+	OwnerAddr  string      `json:"owner_addr"`
+	Status     ShardStatus `json:"status"`
+	TxId       string      `json:"tx_id"`
+	Ctime      int64       `json:"ctime"`
+	JumpLink   string      `json:"jump_link"`
+	Price      int64       `json:"price"`
+	Title      string      `json:"title"`
+	Thumb      []ThumbMap  `json:"thumb"`
+	AssetUrl   []string    `json:"asset_url"`
+	ImgDesc    []string    `json:"img_desc"`
+	ShortDesc  string      `json:"short_desc"`
+	CreateAddr string      `json:"create_addr"`
 }
 
 // ////////// Scene listdiffbyaddr /////////////////
@@ -999,9 +1010,9 @@ type SceneListDiffByAddrParam struct {
 	Addr  string `json:"addr"`
 	Token string `json:"token"`
 	// 可选参数
-	Limit  int    `json:"limit"`
-	Cursor string `json:"cursor"`
-	OpTyps string `json:"op_types"`
+	Limit  int      `json:"limit"`
+	Cursor string   `json:"cursor"`
+	OpTyps []DiffOp `json:"op_types"`
 }
 
 func (t *SceneListDiffByAddrParam) Valid() error {
@@ -1009,17 +1020,7 @@ func (t *SceneListDiffByAddrParam) Valid() error {
 		return ErrParamInvalid
 	}
 
-	if t.OpTyps == "" {
-		return nil
-	}
-
-	var arr []int
-	err := json.Unmarshal([]byte(t.OpTyps), &arr)
-	if err != nil {
-		return ErrParamInvalid
-	}
-
-	return nil
+	return DiffOpsValid(t.OpTyps)
 }
 
 // ////////// Scene hasassetbyaddr ///////////////
@@ -1094,6 +1095,10 @@ type BdBoxBindParam struct {
 	Mnemonic string `json:"mnemonic"`
 }
 
+func (t BdBoxBindParam) String() string {
+	return fmt.Sprintf("{OpenId:%s AppKey:%s Mnemonic:%s}", t.OpenId, t.AppKey, redact.Secret(t.Mnemonic))
+}
+
 func (t *BdBoxBindParam) Valid() error {
 	if t == nil {
 		return ErrNilPointer
@@ -1116,6 +1121,10 @@ type BindByUnionIdParam struct {
 	Mnemonic string `json:"mnemonic"`
 }
 
+func (t BindByUnionIdParam) String() string {
+	return fmt.Sprintf("{UnionId:%s Mnemonic:%s}", redact.Partial(t.UnionId), redact.Secret(t.Mnemonic))
+}
+
 func (t *BindByUnionIdParam) Valid() error {
 	if t == nil {
 		return ErrNilPointer
@@ -0,0 +1,210 @@
+package base
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// 酒店入住、离店日期格式
+const StayDateLayout = "2006-01-02"
+
+var (
+	ErrAssetExtInvalid    = errors.New("asset ext invalid, must be a json object matching the asset category")
+	ErrAssetExtCate       = errors.New("asset ext not supported by the asset category")
+	ErrVenueInvalid       = errors.New("ticket venue invalid, empty string")
+	ErrSessionTimeInvalid = errors.New("ticket session time invalid, end must be after start")
+	ErrHotelInvalid       = errors.New("hotel name invalid, empty string")
+	ErrStayDateInvalid    = errors.New("hotel stay date invalid, check out must be after check in")
+	ErrFaceValueInvalid   = errors.New("coupon face value invalid, must be a positive integer")
+	ErrValidityInvalid    = errors.New("coupon validity invalid, end must be after start")
+)
+
+// 分类扩展信息，序列化后存放在资产的AssetExt字段
+type AssetExtInfo interface {
+	// 是否适用于该资产分类
+	SupportCate(cate AssetType) bool
+	Valid() error
+}
+
+// 门票扩展信息
+// SessionStart、SessionEnd 场次开始、结束时间，秒级时间戳
+type TicketExt struct {
+	Venue        string `json:"venue"`
+	VenueAddr    string `json:"venue_addr,omitempty"`
+	SessionName  string `json:"session_name,omitempty"`
+	SessionStart int64  `json:"session_start"`
+	SessionEnd   int64  `json:"session_end,omitempty"`
+	Seat         string `json:"seat,omitempty"`
+}
+
+func (t *TicketExt) SupportCate(cate AssetType) bool {
+	return cate == AssetCateTicket
+}
+
+func (t *TicketExt) Valid() error {
+	if t == nil {
+		return ErrNilPointer
+	}
+	if err := DescValid(t.Venue); err != nil {
+		return ErrVenueInvalid
+	}
+	if t.SessionStart <= 0 || (t.SessionEnd != 0 && t.SessionEnd <= t.SessionStart) {
+		return ErrSessionTimeInvalid
+	}
+	return nil
+}
+
+// 场次是否已结束，未设置结束时间时以开始时间为准
+func (t *TicketExt) Ended(now time.Time) bool {
+	end := t.SessionEnd
+	if end == 0 {
+		end = t.SessionStart
+	}
+	return now.Unix() > end
+}
+
+// 酒店扩展信息
+// CheckIn、CheckOut 入住、离店日期，格式为 2006-01-02
+type HotelExt struct {
+	HotelName string `json:"hotel_name"`
+	HotelAddr string `json:"hotel_addr,omitempty"`
+	RoomType  string `json:"room_type,omitempty"`
+	CheckIn   string `json:"check_in"`
+	CheckOut  string `json:"check_out"`
+}
+
+func (t *HotelExt) SupportCate(cate AssetType) bool {
+	return cate == AssetCateHotel
+}
+
+func (t *HotelExt) Valid() error {
+	if t == nil {
+		return ErrNilPointer
+	}
+	if err := DescValid(t.HotelName); err != nil {
+		return ErrHotelInvalid
+	}
+	if t.Nights() <= 0 {
+		return ErrStayDateInvalid
+	}
+	return nil
+}
+
+// 入住晚数，日期格式错误时返回0
+func (t *HotelExt) Nights() int {
+	in, err := time.Parse(StayDateLayout, t.CheckIn)
+	if err != nil {
+		return 0
+	}
+	out, err := time.Parse(StayDateLayout, t.CheckOut)
+	if err != nil {
+		return 0
+	}
+	nights := int(out.Sub(in).Hours() / 24)
+	if nights < 0 {
+		return 0
+	}
+	return nights
+}
+
+// 券类扩展信息，适用于现金券和电子券
+// FaceValue 面值，MinSpend 使用门槛，单位均为分
+// ValidFrom、ValidTo 有效期，秒级时间戳，ValidFrom为0表示发放即生效
+type CouponExt struct {
+	FaceValue int64  `json:"face_value"`
+	MinSpend  int64  `json:"min_spend,omitempty"`
+	ValidFrom int64  `json:"valid_from,omitempty"`
+	ValidTo   int64  `json:"valid_to"`
+	Merchant  string `json:"merchant,omitempty"`
+}
+
+func (t *CouponExt) SupportCate(cate AssetType) bool {
+	return cate == AssetCateXJCoupon || cate == AssetCateECoupon
+}
+
+func (t *CouponExt) Valid() error {
+	if t == nil {
+		return ErrNilPointer
+	}
+	if t.FaceValue <= 0 {
+		return ErrFaceValueInvalid
+	}
+	if err := PriceInvalid(t.MinSpend); err != nil {
+		return err
+	}
+	if t.ValidFrom < 0 || t.ValidTo <= t.ValidFrom {
+		return ErrValidityInvalid
+	}
+	return nil
+}
+
+// 是否在有效期内
+func (t *CouponExt) InValidity(now time.Time) bool {
+	ts := now.Unix()
+	return ts >= t.ValidFrom && ts <= t.ValidTo
+}
+
+// NewAssetExt 返回分类对应的空扩展信息，分类没有定义扩展信息时返回nil
+func NewAssetExt(cate AssetType) AssetExtInfo {
+	switch cate {
+	case AssetCateTicket:
+		return &TicketExt{}
+	case AssetCateHotel:
+		return &HotelExt{}
+	case AssetCateXJCoupon, AssetCateECoupon:
+		return &CouponExt{}
+	}
+	return nil
+}
+
+// ParseAssetExt 按资产分类解析AssetExt字段
+// 分类没有定义扩展信息或AssetExt为空时返回nil，与AssetExtValid一致
+func ParseAssetExt(cate AssetType, ext string) (AssetExtInfo, error) {
+	info := NewAssetExt(cate)
+	if info == nil || ext == "" {
+		return nil, nil
+	}
+	if err := json.Unmarshal([]byte(ext), info); err != nil {
+		return nil, ErrAssetExtInvalid
+	}
+	return info, nil
+}
+
+// AssetExtValid 对定义了扩展信息的分类校验AssetExt，为空时不校验
+func AssetExtValid(cate AssetType, ext string) error {
+	info, err := ParseAssetExt(cate, ext)
+	if err != nil || info == nil {
+		return err
+	}
+	return info.Valid()
+}
+
+// SetAssetExt 校验并序列化扩展信息到AssetExt，需先设置AssetCate
+func (t *CreateAssetInfo) SetAssetExt(ext AssetExtInfo) error {
+	if ext == nil {
+		return ErrNilPointer
+	}
+	if !ext.SupportCate(t.AssetCate) {
+		return ErrAssetExtCate
+	}
+	if err := ext.Valid(); err != nil {
+		return err
+	}
+	data, err := json.Marshal(ext)
+	if err != nil {
+		return ErrAssetExtInvalid
+	}
+	t.AssetExt = string(data)
+	return nil
+}
+
+// 解析资产扩展信息，分类没有定义扩展信息时返回nil
+func (t *QueryAssetMeta) ParseAssetExt() (AssetExtInfo, error) {
+	return ParseAssetExt(AssetType(t.AssetCate), t.AssetExt)
+}
+
+// 解析碎片所属资产的扩展信息，分类没有定义扩展信息时返回nil
+func (t *ShardAssetInfo) ParseAssetExt() (AssetExtInfo, error) {
+	return ParseAssetExt(AssetType(t.AssetCate), t.AssetExt)
+}
@@ -0,0 +1,98 @@
+package base
+
+import (
+	"testing"
+	"time"
+)
+
+func testCreateParam(cate AssetType, ext string) *CreateAssetParam {
+	return &CreateAssetParam{
+		Amount:  10,
+		Account: TestAccount,
+		AssetInfo: &CreateAssetInfo{
+			AssetCate: cate,
+			Title:     "title",
+			Thumb:     []string{"bos_v1://a/b/c.jpg"},
+			ShortDesc: "desc",
+			AssetUrl:  []string{"bos_v1://a/b/c.jpg"},
+			AssetExt:  ext,
+		},
+	}
+}
+
+func TestTicketExt(t *testing.T) {
+	p := testCreateParam(AssetCateTicket, "")
+	ticket := &TicketExt{Venue: "国家体育场", SessionStart: 1700000000, SessionEnd: 1700007200, Seat: "A-12"}
+	if err := p.AssetInfo.SetAssetExt(ticket); err != nil {
+		t.Fatalf("set ticket ext failed.err:%v", err)
+	}
+	if err := p.Valid(); err != nil {
+		t.Fatalf("valid failed.err:%v", err)
+	}
+
+	meta := &QueryAssetMeta{AssetCate: int(AssetCateTicket), AssetExt: p.AssetInfo.AssetExt}
+	info, err := meta.ParseAssetExt()
+	if err != nil {
+		t.Fatalf("parse ticket ext failed.err:%v", err)
+	}
+	got, ok := info.(*TicketExt)
+	if !ok || *got != *ticket {
+		t.Fatalf("parse ticket ext error.%+v", info)
+	}
+	if !got.Ended(time.Unix(1700007201, 0)) || got.Ended(time.Unix(1700000000, 0)) {
+		t.Fatal("ticket ended check error")
+	}
+
+	if err := testCreateParam(AssetCateTicket, `{"venue":"x","session_start":10,"session_end":5}`).Valid(); err != ErrSessionTimeInvalid {
+		t.Fatalf("want ErrSessionTimeInvalid, got %v", err)
+	}
+	if err := testCreateParam(AssetCateTicket, `not json`).Valid(); err != ErrAssetExtInvalid {
+		t.Fatalf("want ErrAssetExtInvalid, got %v", err)
+	}
+	// 未定义扩展信息的分类不校验
+	if err := testCreateParam(AssetCateArt, `not json`).Valid(); err != nil {
+		t.Fatalf("art ext should not be checked.err:%v", err)
+	}
+
+	// 没有扩展信息的资产校验和解析都通过
+	if err := testCreateParam(AssetCateTicket, "").Valid(); err != nil {
+		t.Fatalf("empty ext should be valid.err:%v", err)
+	}
+	empty := &QueryAssetMeta{AssetCate: int(AssetCateTicket)}
+	if info, err := empty.ParseAssetExt(); info != nil || err != nil {
+		t.Fatalf("empty ext should parse to nil.%v err:%v", info, err)
+	}
+}
+
+func TestHotelAndCouponExt(t *testing.T) {
+	hotel := &HotelExt{HotelName: "西湖酒店", CheckIn: "2022-05-01", CheckOut: "2022-05-03"}
+	if hotel.Nights() != 2 || hotel.Valid() != nil {
+		t.Fatalf("hotel ext error.%+v", hotel)
+	}
+	if err := testCreateParam(AssetCateHotel, `{"hotel_name":"x","check_in":"2022-05-03","check_out":"2022-05-01"}`).Valid(); err != ErrStayDateInvalid {
+		t.Fatalf("want ErrStayDateInvalid, got %v", err)
+	}
+
+	info := &CreateAssetInfo{AssetCate: AssetCateHotel}
+	if err := info.SetAssetExt(&CouponExt{FaceValue: 100, ValidTo: 10}); err != ErrAssetExtCate {
+		t.Fatalf("want ErrAssetExtCate, got %v", err)
+	}
+
+	coupon := &CouponExt{FaceValue: 500, MinSpend: 2000, ValidFrom: 100, ValidTo: 200}
+	for _, cate := range []AssetType{AssetCateXJCoupon, AssetCateECoupon} {
+		p := testCreateParam(cate, "")
+		if err := p.AssetInfo.SetAssetExt(coupon); err != nil {
+			t.Fatalf("set coupon ext failed.cate:%d err:%v", cate, err)
+		}
+		parsed, err := ParseAssetExt(cate, p.AssetInfo.AssetExt)
+		if err != nil || *parsed.(*CouponExt) != *coupon {
+			t.Fatalf("parse coupon ext error.%+v err:%v", parsed, err)
+		}
+	}
+	if !coupon.InValidity(time.Unix(150, 0)) || coupon.InValidity(time.Unix(201, 0)) {
+		t.Fatal("coupon validity check error")
+	}
+	if err := testCreateParam(AssetCateECoupon, `{"face_value":0,"valid_to":10}`).Valid(); err != ErrFaceValueInvalid {
+		t.Fatalf("want ErrFaceValueInvalid, got %v", err)
+	}
+}
@@ -1,11 +1,14 @@
 package base
 
 import (
+	"context"
 	"crypto/md5"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/xuperchain/xasset-sdk-go/auth"
@@ -122,8 +125,8 @@ func AssetIdValid(assetId int64) error {
 	return nil
 }
 
-func AccountValid(account *auth.Account) error {
-	if account == nil {
+func AccountValid(account auth.Signer) error {
+	if auth.SignerValid(account) != nil {
 		return ErrNilPointer
 	}
 	return nil
@@ -245,10 +248,14 @@ func AppKeyValid(ak string) error {
 	return nil
 }
 
+// 校验单词、数量和校验和，单词错误时返回 *auth.MnemonicWordError
 func MnemonicValid(mnem string) error {
 	if mnem == "" {
 		return ErrMnemInvalid
 	}
+	if _, err := auth.ValidateMnemonic(mnem, 0); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -267,10 +274,25 @@ type BaseResp struct {
 }
 
 // XassetBaseClient
+// 初始化后配置不可变，可并发使用；单次调用的差异通过WithOptions设置
 type XassetBaseClient struct {
-	Cfg         *config.XassetCliConfig
-	Logger      *logs.Logger
+	// InitClient时复制的配置，只读，修改会影响进行中的请求
+	Cfg    *config.XassetCliConfig
+	Logger *logs.Logger
+	// Deprecated: 仅在InitClient时读取一次，之后的修改不生效，使用SetHeader或WithOptions(WithHeader(k, v))
 	ExtraHeader map[string]string
+	// WithOptions得到的副本共享
+	shared *clientShared
+	opts   CallOptions
+	// 未附加调用选项字段的日志，WithOptions据此重建Logger
+	rootLogger *logs.Logger
+}
+
+// SetHeader和Use设置的内容，写时复制
+type clientShared struct {
+	mu          sync.RWMutex
+	header      map[string]string
+	middlewares []Middleware
 }
 
 func (t *XassetBaseClient) InitClient(cfg *config.XassetCliConfig, logger logs.LogDriver) error {
@@ -278,27 +300,79 @@ func (t *XassetBaseClient) InitClient(cfg *config.XassetCliConfig, logger logs.L
 		return ComErrParamInvalid
 	}
 
-	t.Cfg = cfg
+	t.Cfg = cfg.Clone()
 	t.Logger = logs.NewLogger(logger)
+	// 兼容InitClient前写入ExtraHeader的用法，请求时不再读取ExtraHeader
+	header := make(map[string]string, len(t.ExtraHeader))
+	for k, v := range t.ExtraHeader {
+		header[k] = v
+	}
 	t.ExtraHeader = make(map[string]string)
+	t.shared = &clientShared{
+		header:      header,
+		middlewares: []Middleware{NewLogMiddleware(nil)},
+	}
+	t.opts = CallOptions{}
 
 	return nil
 }
 
+// GetConfig 返回配置的副本，修改不影响客户端
 func (t *XassetBaseClient) GetConfig() *config.XassetCliConfig {
-	return t.Cfg
+	return t.Cfg.Clone()
 }
 
+// SetHeader 设置所有调用携带的请求头，可与请求并发执行
 func (t *XassetBaseClient) SetHeader(k, v string) {
-	t.ExtraHeader[k] = v
+	t.shared.mu.Lock()
+	defer t.shared.mu.Unlock()
+	header := make(map[string]string, len(t.shared.header)+1)
+	for hk, hv := range t.shared.header {
+		header[hk] = hv
+	}
+	header[k] = v
+	t.shared.header = header
 }
 
 func (t *XassetBaseClient) Post(uri, data string) (*RequestRes, error) {
-	reqUrl := fmt.Sprintf("%s%s", t.GetConfig().Endpoint, uri)
+	t.shared.mu.RLock()
+	header, middlewares := t.shared.header, t.shared.middlewares
+	t.shared.mu.RUnlock()
+
+	call := &Call{
+		Context: t.opts.Context,
+		Api:     uri,
+		Logger:  t.Logger,
+		Start:   time.Now(),
+	}
+	if call.Context == nil {
+		call.Context = context.Background()
+	}
+	call.Params, _ = url.ParseQuery(data)
+
+	// 生成请求失败时同样经过中间件，Request为空，不发送请求
+	h := t.send
+	t.genRequest(call, data, header)
+	if call.Err != nil {
+		h = func(call *Call) {}
+	}
+	chain(middlewares, h)(call)
+	if call.Err != nil {
+		return nil, call.Err
+	}
+	return call.Result, nil
+}
+
+// 生成签名后的请求，成功时设置call.Request，失败时设置call.Err和call.Cause
+func (t *XassetBaseClient) genRequest(call *Call, data string, extraHeader map[string]string) {
+	fail := func(err, cause error) {
+		call.Err, call.Cause = err, cause
+	}
+	reqUrl := fmt.Sprintf("%s%s", t.Cfg.Endpoint, call.Api)
 	u, err := url.Parse(reqUrl)
 	if err != nil {
-		t.Logger.Warn("url error.[url:%s] [err:%v]", reqUrl, err)
-		return nil, ComErrConfigErr
+		fail(ComErrConfigErr, err)
+		return
 	}
 	header := map[string]string{
 		"Content-Type": "application/x-www-form-urlencoded;charset=utf-8",
@@ -309,43 +383,68 @@ func (t *XassetBaseClient) Post(uri, data string) (*RequestRes, error) {
 
 	req, err := httpcli.GenRequest("POST", reqUrl, header, data)
 	if err != nil {
-		t.Logger.Warn("generate request failed.[err:%v]", err)
-		return nil, ComErrGenRequestFailed
+		fail(ComErrGenRequestFailed, err)
+		return
 	}
-	sign, err := auth.Sign(req, t.GetConfig().Credentials, t.GetConfig().SignOption)
+	sign, err := auth.Sign(req, t.Cfg.Credentials, t.Cfg.SignOption)
 	if err != nil {
-		return nil, ComErrXassetSignFailed
+		fail(ComErrXassetSignFailed, err)
+		return
 	}
 	req.Header.Set("Authorization", sign)
 
-	for k, v := range t.ExtraHeader {
+	for k, v := range extraHeader {
+		req.Header.Set(k, v)
+	}
+	for k, v := range t.opts.Header {
 		req.Header.Set(k, v)
 	}
+	if t.opts.IdempotencyKey != "" {
+		req.Header.Set(HeaderIdempotencyKey, t.opts.IdempotencyKey)
+	}
+	if t.opts.TraceId != "" {
+		req.Header.Set(HeaderTraceId, t.opts.TraceId)
+	}
+	call.Request = req
+}
 
+// 中间件链最内层，发送请求并解析响应中的request_id和errno
+func (t *XassetBaseClient) send(call *Call) {
 	opts := make(map[string]string)
-	if httpcli.IsHttps(reqUrl) {
+	if httpcli.IsHttps(call.Request.URL.String()) {
 		opts[httpcli.OptTlsSipVerify] = "1"
 	}
-	resp, err := httpcli.SendRequest(req, t.GetConfig().ConnectTimeoutMs,
-		t.GetConfig().ReadWriteTimeoutMs, opts)
+	connTimeout, rwTimeout := t.Cfg.ConnectTimeoutMs, t.Cfg.ReadWriteTimeoutMs
+	if t.opts.Timeout > 0 {
+		// 不足1ms时按1ms处理，超时为0会导致请求立即失败
+		rwTimeout = int((t.opts.Timeout + time.Millisecond - 1) / time.Millisecond)
+		if rwTimeout < connTimeout {
+			connTimeout = rwTimeout
+		}
+	}
+	resp, err := httpcli.SendRequest(call.Request.WithContext(call.Context), connTimeout, rwTimeout, opts)
 	if err != nil {
-		t.Logger.Warn("send http request failed.[url:%s] [err:%v]", reqUrl, err)
-		return nil, ComErrRequsetFailed
+		call.Err = ComErrRequsetFailed
+		call.Cause = err
+		return
 	}
 
-	result := &RequestRes{
+	call.Result = &RequestRes{
 		HttpCode: resp.StatusCode,
-		ReqUrl:   reqUrl,
+		ReqUrl:   call.Request.URL.String(),
 		Header:   resp.Header,
 		Body:     string(resp.Body),
 	}
-	return result, nil
+	var baseResp BaseResp
+	if err := json.Unmarshal(resp.Body, &baseResp); err == nil {
+		call.Resp = &baseResp
+	}
 }
 
 func (t *XassetBaseClient) GetTarceId(header http.Header) string {
 	var traceId string
 	if header != nil {
-		traceId = header.Get("xasset-trace-id")
+		traceId = header.Get(HeaderTraceId)
 	}
 
 	if traceId == "" {
@@ -0,0 +1,65 @@
+package base
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/common/logs"
+)
+
+type fieldRecorder struct {
+	level  logs.Level
+	msg    string
+	fields map[string]interface{}
+}
+
+func (t *fieldRecorder) Log(level logs.Level, msg string, fields ...logs.Field) {
+	t.level, t.msg = level, msg
+	t.fields = make(map[string]interface{})
+	for _, f := range fields {
+		t.fields[f.Key] = f.Value
+	}
+}
+
+func TestPostLogFields(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("xasset-trace-id", "trace1")
+		fmt.Fprint(w, `{"request_id":"req1","errno":2001,"errmsg":"asset not exist"}`)
+	}))
+	defer ts.Close()
+
+	cfg := TestGetXassetConfig()
+	cfg.Endpoint = ts.URL
+	rec := &fieldRecorder{}
+	cli := &XassetBaseClient{}
+	if err := cli.InitClient(cfg, logs.NewStructuredDriver(rec)); err != nil {
+		t.Fatalf("init client failed.err:%v", err)
+	}
+	if _, err := cli.Post(AssetApiQueryAsset, "asset_id=123&shard_id=456&addr=x"); err != nil {
+		t.Fatalf("post failed.err:%v", err)
+	}
+
+	want := map[string]interface{}{
+		logs.FieldApi:       AssetApiQueryAsset,
+		logs.FieldAssetId:   int64(123),
+		logs.FieldShardId:   int64(456),
+		logs.FieldHttpCode:  200,
+		logs.FieldErrno:     2001,
+		logs.FieldRequestId: "req1",
+		logs.FieldTraceId:   "trace1",
+	}
+	if rec.level != logs.LevelWarn {
+		t.Fatalf("errno failure should log warn, got %s", rec.level)
+	}
+	for k, v := range want {
+		if rec.fields[k] != v {
+			t.Fatalf("field %s want %v, got %v", k, v, rec.fields[k])
+		}
+	}
+	if _, ok := rec.fields[logs.FieldLatency].(time.Duration); !ok {
+		t.Fatalf("latency field missing")
+	}
+}
@@ -0,0 +1,211 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/common/logs"
+	"github.com/xuperchain/xasset-sdk-go/common/metrics"
+	"github.com/xuperchain/xasset-sdk-go/common/tracing"
+)
+
+// 一次接口调用的上下文
+// 中间件在调用next前可以修改Request，调用next后可以读取Result、Resp和Err
+type Call struct {
+	// 调用上下文，可携带上游span，默认为context.Background()
+	Context context.Context
+	// 接口路径，如AssetApiGrant
+	Api string
+	// 解码后的请求参数，修改不会影响已签名的请求
+	Params url.Values
+	// 已签名的请求，可以添加不参与签名的header，生成请求失败时为空且Err已设置
+	Request *http.Request
+	Result  *RequestRes
+	// 本次调用使用的日志，WithLogger、WithTraceId会替换
+	Logger *logs.Logger
+	// 响应中的request_id和errno，响应不是json时为空
+	Resp *BaseResp
+	// Post最终返回的错误
+	Err error
+	// 发送失败时的底层错误
+	Cause error
+	Start time.Time
+}
+
+// 中间件链中的处理函数，结果记录在call中
+type PostHandler func(call *Call)
+
+// 中间件，包装next实现日志、指标、追踪、故障注入等功能
+type Middleware func(next PostHandler) PostHandler
+
+// Use 追加中间件，先添加的在外层，写时复制，不影响进行中的请求
+// InitClient默认添加了日志中间件
+func (t *XassetBaseClient) Use(mws ...Middleware) {
+	t.shared.mu.Lock()
+	defer t.shared.mu.Unlock()
+	res := make([]Middleware, 0, len(t.shared.middlewares)+len(mws))
+	res = append(res, t.shared.middlewares...)
+	t.shared.middlewares = append(res, mws...)
+}
+
+func chain(mws []Middleware, h PostHandler) PostHandler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// ParamInt64 读取整数类型的请求参数
+func (t *Call) ParamInt64(key string) (int64, bool) {
+	v, err := strconv.ParseInt(t.Params.Get(key), 10, 64)
+	return v, err == nil
+}
+
+// NewTimingMiddleware 统计每次调用耗时
+func NewTimingMiddleware(observe func(call *Call, latency time.Duration)) Middleware {
+	return func(next PostHandler) PostHandler {
+		return func(call *Call) {
+			begin := time.Now()
+			next(call)
+			observe(call, time.Since(begin))
+		}
+	}
+}
+
+// NewLogMiddleware 每次调用结束后输出一条结构化日志，失败为warn级别，成功为trace级别
+// 接口方法不再单独记录请求失败和成功的日志
+// logger为空时使用call.Logger
+func NewLogMiddleware(logger *logs.Logger) Middleware {
+	return NewTimingMiddleware(func(call *Call, latency time.Duration) {
+		l := logger
+		if l == nil {
+			l = call.Logger
+		}
+		l = l.With(callLogFields(call)...)
+		if call.Request == nil {
+			l.Log(logs.LevelWarn, "generate http request failed", logs.F(logs.FieldErr, call.errCause()))
+			return
+		}
+		if call.Result == nil {
+			l.Log(logs.LevelWarn, "send http request failed", logs.F(logs.FieldUrl, call.Request.URL.String()),
+				logs.F(logs.FieldErr, call.errCause()), logs.F(logs.FieldLatency, latency))
+			return
+		}
+
+		level := logs.LevelTrace
+		fields := []logs.Field{logs.F(logs.FieldHttpCode, call.Result.HttpCode)}
+		if call.Resp != nil {
+			fields = append(fields, logs.F(logs.FieldErrno, call.Resp.Errno),
+				logs.F(logs.FieldRequestId, call.Resp.RequestId))
+		}
+		if call.Result.HttpCode != http.StatusOK || call.Resp == nil || call.Resp.Errno != XassetErrNoSucc {
+			level = logs.LevelWarn
+		}
+		// 非200或无法解析时附带响应内容，便于排查
+		if call.Result.HttpCode != http.StatusOK || call.Resp == nil {
+			fields = append(fields, logs.F(logs.FieldUrl, call.Result.ReqUrl), logs.F(logs.FieldBody, call.Result.Body))
+		}
+		if id := call.Result.Header.Get(HeaderTraceId); id != "" {
+			fields = append(fields, logs.F(logs.FieldTraceId, id))
+		}
+		fields = append(fields, logs.F(logs.FieldLatency, latency))
+		l.Log(level, "xasset request finished", fields...)
+	})
+}
+
+// NewMetricsMiddleware 按接口路径、http_code和errno记录调用次数、耗时和进行中的请求数
+// 未收到响应时http_code为metrics.HttpCodeNone，无法解析errno时为metrics.ErrnoUnknown
+func NewMetricsMiddleware(r metrics.Recorder) Middleware {
+	return func(next PostHandler) PostHandler {
+		return func(call *Call) {
+			r.IncInFlight(call.Api)
+			// 内层中间件panic时也要恢复进行中的请求数
+			defer r.DecInFlight(call.Api)
+			begin := time.Now()
+			next(call)
+			latency := time.Since(begin)
+
+			httpCode, errno := metrics.HttpCodeNone, metrics.ErrnoUnknown
+			if call.Result != nil {
+				httpCode = call.Result.HttpCode
+			}
+			if call.Resp != nil {
+				errno = call.Resp.Errno
+			}
+			r.ObserveRequest(call.Api, httpCode, errno, latency)
+		}
+	}
+}
+
+// NewTracingMiddleware 每次调用开始一个span，请求中注入traceparent
+// traceHeader不为空时额外以该header传递trace_id，便于服务端按自定义header关联
+func NewTracingMiddleware(tracer tracing.Tracer, traceHeader string) Middleware {
+	return func(next PostHandler) PostHandler {
+		return func(call *Call) {
+			ctx, span := tracer.Start(call.Context, call.Api)
+			defer span.End()
+			call.Context = ctx
+
+			sc := span.SpanContext()
+			if sc.IsValid() && call.Request != nil {
+				call.Request.Header.Set(tracing.HeaderTraceparent, sc.Traceparent())
+				if traceHeader != "" {
+					call.Request.Header.Set(traceHeader, sc.TraceId.String())
+				}
+			}
+			span.SetAttribute(tracing.AttrApi, call.Api)
+			for k, attr := range map[string]string{
+				logs.FieldAssetId: tracing.AttrAssetId,
+				logs.FieldShardId: tracing.AttrShardId,
+			} {
+				if v, ok := call.ParamInt64(k); ok {
+					span.SetAttribute(attr, v)
+				}
+			}
+
+			next(call)
+
+			if call.Result != nil {
+				span.SetAttribute(tracing.AttrHttpCode, call.Result.HttpCode)
+				if id := call.Result.Header.Get(HeaderTraceId); id != "" {
+					span.SetAttribute(tracing.AttrServerTraceId, id)
+				}
+			}
+			if call.Resp != nil {
+				span.SetAttribute(tracing.AttrErrno, call.Resp.Errno)
+				span.SetAttribute(tracing.AttrRequestId, call.Resp.RequestId)
+			}
+			switch {
+			case call.Err != nil:
+				span.RecordError(call.errCause())
+			case call.Result != nil && call.Result.HttpCode != http.StatusOK:
+				span.RecordError(ComErrRespCodeErr)
+			case call.Resp != nil && call.Resp.Errno != XassetErrNoSucc:
+				span.RecordError(ComErrServRespErrnoErr)
+			}
+		}
+	}
+}
+
+func (t *Call) errCause() error {
+	if t.Cause != nil {
+		return t.Cause
+	}
+	return t.Err
+}
+
+// 接口名以及请求参数中的asset_id、shard_id
+func callLogFields(call *Call) []logs.Field {
+	fields := []logs.Field{logs.F(logs.FieldApi, call.Api)}
+	for _, k := range []string{logs.FieldAssetId, logs.FieldShardId} {
+		if v, ok := call.ParamInt64(k); ok {
+			fields = append(fields, logs.F(k, v))
+		} else if v := call.Params.Get(k); v != "" {
+			fields = append(fields, logs.F(k, v))
+		}
+	}
+	return fields
+}
@@ -0,0 +1,197 @@
+package base
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/common/metrics"
+	"github.com/xuperchain/xasset-sdk-go/common/tracing"
+)
+
+func newTestClient(t *testing.T, h http.HandlerFunc) (*XassetBaseClient, func()) {
+	ts := httptest.NewServer(h)
+	cfg := TestGetXassetConfig()
+	cfg.Endpoint = ts.URL
+	cli := &XassetBaseClient{}
+	if err := cli.InitClient(cfg, nil); err != nil {
+		t.Fatalf("init client failed.err:%v", err)
+	}
+	return cli, ts.Close
+}
+
+func TestMiddlewareChain(t *testing.T) {
+	var hits int
+	cli, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		fmt.Fprintf(w, `{"request_id":"r1","errno":0,"audit":"%s"}`, r.Header.Get("X-Audit"))
+	})
+	defer done()
+
+	var order []string
+	var latency time.Duration
+	mw := func(name string) Middleware {
+		return func(next PostHandler) PostHandler {
+			return func(call *Call) {
+				order = append(order, name+" before")
+				call.Request.Header.Set("X-Audit", call.Params.Get("asset_id"))
+				next(call)
+				order = append(order, name+" after")
+			}
+		}
+	}
+	cli.Use(mw("a"), mw("b"), NewTimingMiddleware(func(call *Call, d time.Duration) {
+		latency = d
+	}))
+
+	res, err := cli.Post(AssetApiGrant, "asset_id=100&addr=x")
+	if err != nil {
+		t.Fatalf("post failed.err:%v", err)
+	}
+	if res.Body != `{"request_id":"r1","errno":0,"audit":"100"}` {
+		t.Fatalf("custom header not sent.body:%s", res.Body)
+	}
+	want := []string{"a before", "b before", "b after", "a after"}
+	if fmt.Sprint(order) != fmt.Sprint(want) {
+		t.Fatalf("middleware order error.%v", order)
+	}
+	if latency <= 0 || hits != 1 {
+		t.Fatalf("timing middleware not called")
+	}
+}
+
+func TestMiddlewareFaultInjection(t *testing.T) {
+	var hits int
+	cli, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits++
+	})
+	defer done()
+
+	errInject := errors.New("injected")
+	var seen *Call
+	cli.Use(func(next PostHandler) PostHandler {
+		return func(call *Call) {
+			next(call)
+			seen = call
+		}
+	}, func(next PostHandler) PostHandler {
+		return func(call *Call) {
+			call.Err = errInject
+		}
+	})
+
+	if _, err := cli.Post(AssetApiGrant, "asset_id=1"); err != errInject {
+		t.Fatalf("want injected error, got %v", err)
+	}
+	if hits != 0 {
+		t.Fatalf("request should not be sent")
+	}
+	if seen == nil || seen.Api != AssetApiGrant || seen.Err != errInject || seen.Request == nil {
+		t.Fatalf("outer middleware should see final error")
+	}
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	cli, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"request_id":"r1","errno":2001}`)
+	})
+	defer done()
+
+	m := metrics.NewMemory(nil)
+	cli.Use(NewMetricsMiddleware(m))
+	cli.Post(AssetApiGrant, "asset_id=1")
+	cli.Post(AssetApiGrant, "asset_id=1")
+
+	stats := m.Requests()
+	if len(stats) != 1 || stats[0].Api != AssetApiGrant || stats[0].HttpCode != 200 ||
+		stats[0].Errno != 2001 || stats[0].Count != 2 {
+		t.Fatalf("metrics error.%+v", stats)
+	}
+	if m.InFlight()[AssetApiGrant] != 0 {
+		t.Fatalf("in flight should be 0")
+	}
+
+	// 内层panic后进行中的请求数恢复
+	cli.Use(func(next PostHandler) PostHandler {
+		return func(call *Call) { panic("fault injection") }
+	})
+	func() {
+		defer func() { recover() }()
+		cli.Post(AssetApiGrant, "asset_id=1")
+	}()
+	if m.InFlight()[AssetApiGrant] != 0 {
+		t.Fatalf("in flight should be 0 after panic")
+	}
+}
+
+func TestMiddlewareSeesGenRequestError(t *testing.T) {
+	cli, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("request should not be sent")
+	})
+	defer done()
+	cli.Cfg.Endpoint = "http://[::1"
+
+	m := metrics.NewMemory(nil)
+	var seen *Call
+	cli.Use(NewMetricsMiddleware(m), NewTracingMiddleware(tracing.NewMemoryTracer(), ""),
+		func(next PostHandler) PostHandler {
+			return func(call *Call) {
+				next(call)
+				seen = call
+			}
+		})
+	if _, err := cli.Post(AssetApiGrant, ""); err != ComErrConfigErr {
+		t.Fatalf("want ComErrConfigErr, got %v", err)
+	}
+	if seen == nil || seen.Err != ComErrConfigErr || seen.Cause == nil || seen.Request != nil {
+		t.Fatalf("middleware should see gen request error")
+	}
+	if stats := m.Requests(); len(stats) != 1 || stats[0].HttpCode != metrics.HttpCodeNone {
+		t.Fatalf("metrics error.%+v", stats)
+	}
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	var traceparent, traceHeader string
+	cli, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		traceparent, traceHeader = r.Header.Get("traceparent"), r.Header.Get("X-Trace-Id")
+		w.Header().Set("xasset-trace-id", "srv1")
+		fmt.Fprint(w, `{"request_id":"r1","errno":0}`)
+	})
+	defer done()
+
+	tracer := tracing.NewMemoryTracer()
+	cli.Use(NewTracingMiddleware(tracer, "X-Trace-Id"))
+	if _, err := cli.Post(AssetApiGrant, "asset_id=100&shard_id=200"); err != nil {
+		t.Fatalf("post failed.err:%v", err)
+	}
+
+	spans := tracer.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("want 1 span, got %d", len(spans))
+	}
+	s := spans[0]
+	if traceparent != s.Context.Traceparent() || traceHeader != s.Context.TraceId.String() {
+		t.Fatalf("trace headers not injected.%s %s", traceparent, traceHeader)
+	}
+	want := map[string]interface{}{
+		tracing.AttrApi:           AssetApiGrant,
+		tracing.AttrAssetId:       int64(100),
+		tracing.AttrShardId:       int64(200),
+		tracing.AttrHttpCode:      200,
+		tracing.AttrErrno:         0,
+		tracing.AttrRequestId:     "r1",
+		tracing.AttrServerTraceId: "srv1",
+	}
+	for k, v := range want {
+		if s.Attributes[k] != v {
+			t.Fatalf("attribute %s want %v, got %v", k, v, s.Attributes[k])
+		}
+	}
+	if s.Err != nil {
+		t.Fatalf("span should not record error")
+	}
+}
@@ -0,0 +1,219 @@
+package base
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+)
+
+// 离线签名请求格式版本
+const OfflineReqVersion = 1
+
+// 支持离线签名的操作
+type OfflineOp string
+
+const (
+	OfflineOpCreate   OfflineOp = "create"
+	OfflineOpPublish  OfflineOp = "publish"
+	OfflineOpGrant    OfflineOp = "grant"
+	OfflineOpTransfer OfflineOp = "transfer"
+)
+
+var (
+	ErrOfflineVersion  = errors.New("offline request version unsupported")
+	ErrOfflineOp       = errors.New("offline request op unsupported")
+	ErrOfflineReq      = errors.New("offline request invalid")
+	ErrOfflineSigner   = errors.New("signer address does not match offline request")
+	ErrOfflineSign     = errors.New("offline request sign invalid")
+	ErrOfflineSeal     = errors.New("offline request modified after signing")
+	ErrOfflineReserved = errors.New("offline request fields contain reserved key")
+)
+
+// 由签名步骤填充的请求字段，不允许出现在Fields中
+var offlineReserved = []string{"asset_id", "addr", "sign", "pkey", "nonce"}
+
+func (t OfflineOp) Valid() error {
+	switch t {
+	case OfflineOpCreate, OfflineOpPublish, OfflineOpGrant, OfflineOpTransfer:
+		return nil
+	}
+	return ErrOfflineOp
+}
+
+// 待签名请求，在线机器生成，序列化后拷贝到离线机器签名
+// 平台签名只覆盖asset_id和nonce，Fields为请求的其余字段，离线签名前应人工核对
+type UnsignedReq struct {
+	Version   int               `json:"version"`
+	Op        OfflineOp         `json:"op"`
+	AssetId   int64             `json:"asset_id"`
+	Nonce     int64             `json:"nonce"`
+	Addr      string            `json:"addr"`
+	Fields    map[string]string `json:"fields"`
+	CreatedAt int64             `json:"created_at"`
+}
+
+// NewUnsignedReq 创建待签名请求，addr为签名账户地址
+func NewUnsignedReq(op OfflineOp, assetId, nonce int64, addr string, fields url.Values) (*UnsignedReq, error) {
+	t := &UnsignedReq{
+		Version:   OfflineReqVersion,
+		Op:        op,
+		AssetId:   assetId,
+		Nonce:     nonce,
+		Addr:      addr,
+		Fields:    make(map[string]string, len(fields)),
+		CreatedAt: time.Now().Unix(),
+	}
+	for k := range fields {
+		t.Fields[k] = fields.Get(k)
+	}
+	if err := t.Valid(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *UnsignedReq) Valid() error {
+	if t == nil {
+		return ErrNilPointer
+	}
+	if t.Version != OfflineReqVersion {
+		return ErrOfflineVersion
+	}
+	if err := t.Op.Valid(); err != nil {
+		return err
+	}
+	if err := AssetIdValid(t.AssetId); err != nil {
+		return err
+	}
+	if err := AddrValid(t.Addr); err != nil {
+		return err
+	}
+	if t.Nonce < 1 {
+		return ErrOfflineReq
+	}
+	for _, k := range offlineReserved {
+		if _, ok := t.Fields[k]; ok {
+			return ErrOfflineReserved
+		}
+	}
+	return nil
+}
+
+// SignMsg 平台要求的签名原文
+func (t *UnsignedReq) SignMsg() []byte {
+	return []byte(fmt.Sprintf("%d%d", t.AssetId, t.Nonce))
+}
+
+// Summary 供离线签名前核对的可读摘要，按字段名排序
+func (t *UnsignedReq) Summary() string {
+	s := fmt.Sprintf("op:%s\nasset_id:%d\naddr:%s\nnonce:%d\n", t.Op, t.AssetId, t.Addr, t.Nonce)
+	keys := make([]string, 0, len(t.Fields))
+	for k := range t.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		s += fmt.Sprintf("%s:%s\n", k, t.Fields[k])
+	}
+	return s
+}
+
+// SignedBody 使用平台签名和公钥生成请求体
+func (t *UnsignedReq) SignedBody(sign, pkey string) string {
+	v := url.Values{}
+	for k, f := range t.Fields {
+		v.Set(k, f)
+	}
+	v.Set("asset_id", strconv.FormatInt(t.AssetId, 10))
+	v.Set("addr", t.Addr)
+	v.Set("sign", sign)
+	v.Set("pkey", pkey)
+	v.Set("nonce", strconv.FormatInt(t.Nonce, 10))
+	return v.Encode()
+}
+
+// 请求的摘要，json序列化时map按键排序，结果稳定
+func (t *UnsignedReq) digest() ([]byte, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, ComErrJsonMarFailed
+	}
+	sum := sha256.Sum256(data)
+	return []byte("xasset-offline:" + hex.EncodeToString(sum[:])), nil
+}
+
+// Sign 离线机器使用签名者签名，签名者地址必须与请求地址一致
+// 除平台签名外，还对整个请求做一次封签，提交前可检测传输过程中的篡改
+func (t *UnsignedReq) Sign(signer auth.Signer) (*SignedReq, error) {
+	if err := t.Valid(); err != nil {
+		return nil, err
+	}
+	if err := AccountValid(signer); err != nil {
+		return nil, err
+	}
+	if signer.GetAddress() != t.Addr {
+		return nil, ErrOfflineSigner
+	}
+	sign, err := signer.Sign(t.SignMsg())
+	if err != nil {
+		return nil, ComErrAccountSignFailed
+	}
+	digest, err := t.digest()
+	if err != nil {
+		return nil, err
+	}
+	seal, err := signer.Sign(digest)
+	if err != nil {
+		return nil, ComErrAccountSignFailed
+	}
+	return &SignedReq{UnsignedReq: *t, Sign: sign, PKey: signer.GetPublicKey(), Seal: seal}, nil
+}
+
+// 已签名请求，拷贝回在线机器提交
+type SignedReq struct {
+	UnsignedReq
+	Sign string `json:"sign"`
+	PKey string `json:"pkey"`
+	Seal string `json:"seal"`
+}
+
+// Valid 校验公钥与地址匹配、平台签名和封签
+func (t *SignedReq) Valid() error {
+	if t == nil {
+		return ErrNilPointer
+	}
+	if err := t.UnsignedReq.Valid(); err != nil {
+		return err
+	}
+	pub, err := auth.GetEcdsaPubKeyByJsStr(t.PKey)
+	if err != nil {
+		return ErrOfflineSign
+	}
+	if addr, err := auth.GetAddrByPubKey(pub); err != nil || addr != t.Addr {
+		return ErrOfflineSigner
+	}
+	if ok, _ := auth.XassetVerify(t.PKey, t.Sign, t.SignMsg()); !ok {
+		return ErrOfflineSign
+	}
+	digest, err := t.UnsignedReq.digest()
+	if err != nil {
+		return err
+	}
+	if ok, _ := auth.XassetVerify(t.PKey, t.Seal, digest); !ok {
+		return ErrOfflineSeal
+	}
+	return nil
+}
+
+// Body 生成提交给平台的请求体
+func (t *SignedReq) Body() string {
+	return t.UnsignedReq.SignedBody(t.Sign, t.PKey)
+}
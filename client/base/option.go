@@ -0,0 +1,96 @@
+package base
+
+import (
+	"context"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/common/logs"
+)
+
+const (
+	// 幂等键请求头
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// 服务端trace_id请求头，请求时携带则沿用该trace_id
+	HeaderTraceId = "xasset-trace-id"
+)
+
+// 单次调用选项
+type CallOptions struct {
+	Header map[string]string
+	// 读写超时，为0时使用配置中的ReadWriteTimeoutMs
+	Timeout        time.Duration
+	IdempotencyKey string
+	TraceId        string
+	Logger         *logs.Logger
+	Context        context.Context
+}
+
+type CallOption func(opts *CallOptions)
+
+// WithHeader 添加请求头，不参与签名
+func WithHeader(k, v string) CallOption {
+	return func(opts *CallOptions) {
+		header := make(map[string]string, len(opts.Header)+1)
+		for hk, hv := range opts.Header {
+			header[hk] = hv
+		}
+		header[k] = v
+		opts.Header = header
+	}
+}
+
+// WithTimeout 设置读写超时，连接超时不超过该值，不足1ms按1ms处理
+func WithTimeout(d time.Duration) CallOption {
+	return func(opts *CallOptions) {
+		opts.Timeout = d
+	}
+}
+
+// WithIdempotencyKey 设置幂等键，服务端据此识别重复请求
+func WithIdempotencyKey(key string) CallOption {
+	return func(opts *CallOptions) {
+		opts.IdempotencyKey = key
+	}
+}
+
+// WithTraceId 指定trace_id，随请求发送并输出到日志
+func WithTraceId(id string) CallOption {
+	return func(opts *CallOptions) {
+		opts.TraceId = id
+	}
+}
+
+// WithLogger 替换日志驱动
+func WithLogger(logger logs.LogDriver) CallOption {
+	return func(opts *CallOptions) {
+		opts.Logger = logs.NewLogger(logger)
+	}
+}
+
+// WithContext 设置调用上下文，用于取消请求和关联上游span
+func WithContext(ctx context.Context) CallOption {
+	return func(opts *CallOptions) {
+		opts.Context = ctx
+	}
+}
+
+// WithOptions 返回应用了调用选项的客户端副本，原客户端不受影响，可并发使用
+// 副本与原客户端共享配置、SetHeader设置的请求头和中间件
+func (t *XassetBaseClient) WithOptions(opts ...CallOption) *XassetBaseClient {
+	res := *t
+	for _, opt := range opts {
+		opt(&res.opts)
+	}
+	// 按最终选项重建Logger，避免WithLogger丢失之前WithTraceId添加的字段
+	if res.rootLogger == nil {
+		res.rootLogger = t.Logger
+	}
+	res.Logger = res.rootLogger
+	if res.opts.Logger != nil {
+		res.Logger = res.opts.Logger
+	}
+	if res.opts.TraceId != "" {
+		res.Logger = res.Logger.With(logs.F(logs.FieldTraceId, res.opts.TraceId))
+	}
+	return &res
+}
@@ -0,0 +1,132 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/common/logs"
+)
+
+func TestCallOptions(t *testing.T) {
+	cli, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Slow") != "" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		fmt.Fprintf(w, `{"errno":0,"idem":"%s","trace":"%s","custom":"%s"}`, r.Header.Get(HeaderIdempotencyKey),
+			r.Header.Get(HeaderTraceId), r.Header.Get("X-Custom"))
+	})
+	defer done()
+
+	logger := &fieldRecorder{}
+	derived := cli.WithOptions(WithHeader("X-Custom", "c1"), WithIdempotencyKey("k1"),
+		WithTraceId("t1"), WithLogger(logs.NewStructuredDriver(logger)))
+	res, err := derived.Post(AssetApiGrant, "")
+	if err != nil {
+		t.Fatalf("post failed.err:%v", err)
+	}
+	if res.Body != `{"errno":0,"idem":"k1","trace":"t1","custom":"c1"}` {
+		t.Fatalf("options not applied.body:%s", res.Body)
+	}
+	if logger.msg == "" || logger.fields["trace_id"] != "t1" {
+		t.Fatalf("logger override not applied")
+	}
+
+	// 之后的WithLogger不丢失trace_id字段
+	logger = &fieldRecorder{}
+	cli.WithOptions(WithTraceId("t2")).WithOptions(WithLogger(logs.NewStructuredDriver(logger))).Post(AssetApiGrant, "")
+	if logger.fields["trace_id"] != "t2" {
+		t.Fatalf("trace_id lost after WithLogger.%v", logger.fields)
+	}
+
+	// 原客户端不受影响
+	res, _ = cli.Post(AssetApiGrant, "")
+	if res.Body != `{"errno":0,"idem":"","trace":"","custom":""}` {
+		t.Fatalf("origin client changed.body:%s", res.Body)
+	}
+
+	slow := cli.WithOptions(WithHeader("X-Slow", "1"))
+	if _, err := slow.WithOptions(WithTimeout(50*time.Millisecond)).Post(AssetApiGrant, ""); err != ComErrRequsetFailed {
+		t.Fatalf("want timeout, got %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := slow.WithOptions(WithContext(ctx)).Post(AssetApiGrant, ""); err != ComErrRequsetFailed {
+		t.Fatalf("want canceled, got %v", err)
+	}
+}
+
+func TestExtraHeader(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"errno":0,"a":"%s","b":"%s"}`, r.Header.Get("X-A"), r.Header.Get("X-B"))
+	}))
+	defer ts.Close()
+	cfg := TestGetXassetConfig()
+	cfg.Endpoint = ts.URL
+	cli := &XassetBaseClient{ExtraHeader: map[string]string{"X-A": "a"}}
+	if err := cli.InitClient(cfg, nil); err != nil {
+		t.Fatalf("init client failed.err:%v", err)
+	}
+	// 初始化后直接写入不生效
+	cli.ExtraHeader["X-B"] = "b"
+	res, err := cli.Post(AssetApiGrant, "")
+	if err != nil || res.Body != `{"errno":0,"a":"a","b":""}` {
+		t.Fatalf("extra header error.body:%v err:%v", res, err)
+	}
+}
+
+func TestConfigImmutable(t *testing.T) {
+	cfg := TestGetXassetConfig()
+	cli := &XassetBaseClient{}
+	if err := cli.InitClient(cfg, nil); err != nil {
+		t.Fatalf("init client failed.err:%v", err)
+	}
+	cfg.Endpoint = "http://127.0.0.1:1"
+	cfg.Credentials.SecretAccessKey = "changed"
+	if cli.GetConfig().Endpoint == cfg.Endpoint || cli.GetConfig().Credentials.SecretAccessKey == "changed" {
+		t.Fatalf("client config should not change with origin")
+	}
+	cli.GetConfig().Endpoint = "http://127.0.0.1:2"
+	if cli.Cfg.Endpoint == "http://127.0.0.1:2" {
+		t.Fatalf("GetConfig should return a copy")
+	}
+}
+
+func TestConcurrentCalls(t *testing.T) {
+	cli, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"errno":0,"req":"%s"}`, r.Header.Get("X-Req"))
+	})
+	defer done()
+
+	var wg sync.WaitGroup
+	errs := make(chan string, 100)
+	for i := 0; i < 20; i++ {
+		wg.Add(3)
+		go func(i int) {
+			defer wg.Done()
+			id := fmt.Sprintf("r%d", i)
+			res, err := cli.WithOptions(WithHeader("X-Req", id)).Post(AssetApiGrant, "")
+			if err != nil || !strings.Contains(res.Body, `"req":"`+id+`"`) {
+				errs <- fmt.Sprintf("call %s got %v %v", id, res, err)
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			cli.SetHeader(fmt.Sprintf("X-Set-%d", i), "1")
+		}(i)
+		go func() {
+			defer wg.Done()
+			cli.Use(NewTimingMiddleware(func(*Call, time.Duration) {}))
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for e := range errs {
+		t.Fatal(e)
+	}
+}
@@ -0,0 +1,314 @@
+package base
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 资产状态
+type AssetStatus int
+
+const (
+	// 1.初始化
+	AssetStatusInit AssetStatus = 1
+	// 3.发行中
+	AssetStatusPublishing AssetStatus = 3
+	// 4.已发行
+	AssetStatusPublished AssetStatus = 4
+	// 5.冻结中
+	AssetStatusFreezing AssetStatus = 5
+	// 6.已冻结
+	AssetStatusFrozen AssetStatus = 6
+)
+
+var assetStatusNames = map[AssetStatus]string{
+	AssetStatusInit:       "init",
+	AssetStatusPublishing: "publishing",
+	AssetStatusPublished:  "published",
+	AssetStatusFreezing:   "freezing",
+	AssetStatusFrozen:     "frozen",
+}
+
+func (s AssetStatus) String() string {
+	return enumString(assetStatusNames[s], int(s))
+}
+
+func (s AssetStatus) Valid() error {
+	if _, ok := assetStatusNames[s]; !ok {
+		return ErrStatusInvalid
+	}
+	return nil
+}
+
+// 已发行的资产才能授予碎片
+func (s AssetStatus) IsGrantable() bool {
+	return s == AssetStatusPublished
+}
+
+func (s AssetStatus) IsFrozen() bool {
+	return s == AssetStatusFreezing || s == AssetStatusFrozen
+}
+
+func (s AssetStatus) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(s))), nil
+}
+
+func (s *AssetStatus) UnmarshalJSON(data []byte) error {
+	v, ok, err := unmarshalEnum(data, func(name string) (int, bool) {
+		for k, n := range assetStatusNames {
+			if n == name {
+				return int(k), true
+			}
+		}
+		return 0, false
+	})
+	if err != nil || !ok {
+		return err
+	}
+	*s = AssetStatus(v)
+	return nil
+}
+
+// 碎片状态
+type ShardStatus int
+
+const (
+	// 0.已上链
+	ShardStatusOnChain ShardStatus = 0
+	// 1.授予中
+	ShardStatusGranting ShardStatus = 1
+	// 4.转移中
+	ShardStatusTransferring ShardStatus = 4
+	// 5.核销中
+	ShardStatusConsuming ShardStatus = 5
+	// 6.已核销
+	ShardStatusConsumed ShardStatus = 6
+	// 10.已冻结
+	ShardStatusFrozen ShardStatus = 10
+)
+
+var shardStatusNames = map[ShardStatus]string{
+	ShardStatusOnChain:      "on_chain",
+	ShardStatusGranting:     "granting",
+	ShardStatusTransferring: "transferring",
+	ShardStatusConsuming:    "consuming",
+	ShardStatusConsumed:     "consumed",
+	ShardStatusFrozen:       "frozen",
+}
+
+func (s ShardStatus) String() string {
+	return enumString(shardStatusNames[s], int(s))
+}
+
+func (s ShardStatus) Valid() error {
+	if _, ok := shardStatusNames[s]; !ok {
+		return ErrStatusInvalid
+	}
+	return nil
+}
+
+// 返回指针，用于ListShardsByAddrParam.Status等可选参数
+func (s ShardStatus) Ptr() *ShardStatus {
+	return &s
+}
+
+// 已上链且未处于其他操作中的碎片才能转移或核销
+func (s ShardStatus) IsTransferable() bool {
+	return s == ShardStatusOnChain
+}
+
+func (s ShardStatus) IsConsumed() bool {
+	return s == ShardStatusConsumed
+}
+
+func (s ShardStatus) IsFrozen() bool {
+	return s == ShardStatusFrozen
+}
+
+// 授予、转移、核销操作尚未上链完成
+func (s ShardStatus) IsPending() bool {
+	return s == ShardStatusGranting || s == ShardStatusTransferring || s == ShardStatusConsuming
+}
+
+func (s ShardStatus) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(s))), nil
+}
+
+func (s *ShardStatus) UnmarshalJSON(data []byte) error {
+	v, ok, err := unmarshalEnum(data, func(name string) (int, bool) {
+		for k, n := range shardStatusNames {
+			if n == name {
+				return int(k), true
+			}
+		}
+		return 0, false
+	})
+	if err != nil || !ok {
+		return err
+	}
+	*s = ShardStatus(v)
+	return nil
+}
+
+// 资产历史记录类型
+type HistoryType int
+
+const (
+	// 1.发行
+	HistoryTypePublish HistoryType = 1
+	// 2.授予
+	HistoryTypeGrant HistoryType = 2
+	// 3.转移
+	HistoryTypeTransfer HistoryType = 3
+	// 4.核销
+	HistoryTypeConsume HistoryType = 4
+	// 5.冻结
+	HistoryTypeFreeze HistoryType = 5
+)
+
+var historyTypeNames = map[HistoryType]string{
+	HistoryTypePublish:  "publish",
+	HistoryTypeGrant:    "grant",
+	HistoryTypeTransfer: "transfer",
+	HistoryTypeConsume:  "consume",
+	HistoryTypeFreeze:   "freeze",
+}
+
+func (s HistoryType) String() string {
+	return enumString(historyTypeNames[s], int(s))
+}
+
+func (s HistoryType) Valid() error {
+	if _, ok := historyTypeNames[s]; !ok {
+		return ErrStatusInvalid
+	}
+	return nil
+}
+
+func (s HistoryType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(s))), nil
+}
+
+func (s *HistoryType) UnmarshalJSON(data []byte) error {
+	v, ok, err := unmarshalEnum(data, func(name string) (int, bool) {
+		for k, n := range historyTypeNames {
+			if n == name {
+				return int(k), true
+			}
+		}
+		return 0, false
+	})
+	if err != nil || !ok {
+		return err
+	}
+	*s = HistoryType(v)
+	return nil
+}
+
+// 地址资产变更操作类型
+type DiffOp int
+
+const (
+	// 1.获得授予
+	DiffOpGrant DiffOp = 1
+	// 2.转入
+	DiffOpTransferIn DiffOp = 2
+	// 3.转出
+	DiffOpTransferOut DiffOp = 3
+	// 4.核销
+	DiffOpConsume DiffOp = 4
+)
+
+var diffOpNames = map[DiffOp]string{
+	DiffOpGrant:       "grant",
+	DiffOpTransferIn:  "transfer_in",
+	DiffOpTransferOut: "transfer_out",
+	DiffOpConsume:     "consume",
+}
+
+func (s DiffOp) String() string {
+	return enumString(diffOpNames[s], int(s))
+}
+
+func (s DiffOp) Valid() error {
+	if _, ok := diffOpNames[s]; !ok {
+		return ErrStatusInvalid
+	}
+	return nil
+}
+
+func (s DiffOp) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(s))), nil
+}
+
+func (s *DiffOp) UnmarshalJSON(data []byte) error {
+	v, ok, err := unmarshalEnum(data, func(name string) (int, bool) {
+		for k, n := range diffOpNames {
+			if n == name {
+				return int(k), true
+			}
+		}
+		return 0, false
+	})
+	if err != nil || !ok {
+		return err
+	}
+	*s = DiffOp(v)
+	return nil
+}
+
+// DiffOpsValid 校验操作类型列表，空列表表示不过滤
+func DiffOpsValid(ops []DiffOp) error {
+	for _, op := range ops {
+		if err := op.Valid(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EncodeDiffOps 序列化为接口要求的op_types参数，如 [1,2]
+func EncodeDiffOps(ops []DiffOp) string {
+	if len(ops) == 0 {
+		return ""
+	}
+	strs := make([]string, 0, len(ops))
+	for _, op := range ops {
+		strs = append(strs, strconv.Itoa(int(op)))
+	}
+	return "[" + strings.Join(strs, ",") + "]"
+}
+
+func enumString(name string, v int) string {
+	if name == "" {
+		return fmt.Sprintf("unknown(%d)", v)
+	}
+	return name
+}
+
+// 兼容数字、数字字符串和名称三种格式，未知的数字值原样保留
+// 值为null时ok为false，调用方保持原值不变
+func unmarshalEnum(data []byte, byName func(name string) (int, bool)) (int, bool, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return 0, false, nil
+	}
+	var num int
+	if err := json.Unmarshal(data, &num); err == nil {
+		return num, true, nil
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return 0, false, fmt.Errorf("enum value invalid: %s", data)
+	}
+	if num, err := strconv.Atoi(str); err == nil {
+		return num, true, nil
+	}
+	if v, ok := byName(strings.ToLower(str)); ok {
+		return v, true, nil
+	}
+	return 0, false, fmt.Errorf("enum value invalid: %s", data)
+}
@@ -0,0 +1,88 @@
+package base
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestShardStatusJSON(t *testing.T) {
+	var meta QueryShardMeta
+	if err := json.Unmarshal([]byte(`{"status":6}`), &meta); err != nil {
+		t.Fatalf("unmarshal failed.err:%v", err)
+	}
+	if !meta.Status.IsConsumed() || meta.Status.IsTransferable() || meta.Status.String() != "consumed" {
+		t.Fatalf("status error.%v", meta.Status)
+	}
+
+	// 兼容字符串格式
+	for _, in := range []string{`"6"`, `"consumed"`, `"CONSUMED"`} {
+		var s ShardStatus
+		if err := json.Unmarshal([]byte(in), &s); err != nil || s != ShardStatusConsumed {
+			t.Fatalf("unmarshal %s failed.status:%v err:%v", in, s, err)
+		}
+	}
+	s := ShardStatusFrozen
+	if err := json.Unmarshal([]byte(`null`), &s); err != nil || s != ShardStatusFrozen {
+		t.Fatalf("null should keep value.status:%v err:%v", s, err)
+	}
+	if err := json.Unmarshal([]byte(`"burned"`), &s); err == nil {
+		t.Fatal("unknown name should fail")
+	}
+
+	// 未知数值保留原值，由Valid判定
+	if err := json.Unmarshal([]byte(`99`), &s); err != nil || s != 99 {
+		t.Fatalf("unknown number should keep.status:%v err:%v", s, err)
+	}
+	if s.Valid() != ErrStatusInvalid || s.String() != "unknown(99)" {
+		t.Fatalf("unknown status check error.%v", s)
+	}
+
+	out, _ := json.Marshal(&QueryShardMeta{Status: ShardStatusOnChain})
+	var raw map[string]interface{}
+	json.Unmarshal(out, &raw)
+	if raw["status"] != float64(0) {
+		t.Fatalf("marshal status error.%s", out)
+	}
+}
+
+func TestAssetStatusAndHistoryType(t *testing.T) {
+	var meta QueryAssetMeta
+	if err := json.Unmarshal([]byte(`{"status":4}`), &meta); err != nil {
+		t.Fatalf("unmarshal failed.err:%v", err)
+	}
+	if !meta.Status.IsGrantable() || meta.Status.IsFrozen() || meta.Status.Valid() != nil {
+		t.Fatalf("asset status error.%v", meta.Status)
+	}
+
+	var his HistoryMeta
+	if err := json.Unmarshal([]byte(`{"type":"transfer"}`), &his); err != nil || his.Type != HistoryTypeTransfer {
+		t.Fatalf("history type error.%v err:%v", his.Type, err)
+	}
+}
+
+func TestDiffOps(t *testing.T) {
+	if s := EncodeDiffOps([]DiffOp{DiffOpGrant, DiffOpTransferIn}); s != "[1,2]" {
+		t.Fatalf("encode op types error.%s", s)
+	}
+	if s := EncodeDiffOps(nil); s != "" {
+		t.Fatalf("encode empty op types error.%s", s)
+	}
+
+	p := &ListDiffByAddrParam{Addr: "addr", OpTyps: []DiffOp{DiffOpConsume}}
+	if err := p.Valid(); err != nil {
+		t.Fatalf("valid op types failed.err:%v", err)
+	}
+	p.OpTyps = append(p.OpTyps, DiffOp(9))
+	if err := p.Valid(); err != ErrStatusInvalid {
+		t.Fatalf("invalid op types should fail.err:%v", err)
+	}
+
+	lp := &ListShardsByAddrParam{Addr: "addr", Page: 1, Status: ShardStatusOnChain.Ptr()}
+	if err := lp.Valid(); err != nil {
+		t.Fatalf("valid status failed.err:%v", err)
+	}
+	lp.Status = ShardStatus(3).Ptr()
+	if err := lp.Valid(); err != ErrStatusInvalid {
+		t.Fatalf("invalid status should fail.err:%v", err)
+	}
+}
@@ -0,0 +1,177 @@
+package checkin
+
+import (
+	"bytes"
+	"encoding/json"
+	"image/png"
+	"sync"
+	"testing"
+	"time"
+
+	xbase "github.com/xuperchain/xasset-sdk-go/client/base"
+)
+
+type testRedeemer struct {
+	mu       sync.Mutex
+	meta     *xbase.QueryShardMeta
+	consumed []*xbase.ConsumeShardParam
+}
+
+func (t *testRedeemer) QueryShard(param *xbase.QueryShardParam) (*xbase.QueryShardResp, *xbase.RequestRes, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	meta := *t.meta
+	return &xbase.QueryShardResp{Meta: &meta}, nil, nil
+}
+
+func (t *testRedeemer) ConsumeShard(param *xbase.ConsumeShardParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.consumed = append(t.consumed, param)
+	return &xbase.BaseResp{RequestId: "req-1"}, nil, nil
+}
+
+func newTestRedeemer(owner string, ext string) *testRedeemer {
+	return &testRedeemer{
+		meta: &xbase.QueryShardMeta{
+			AssetId:   100,
+			ShardId:   200,
+			OwnerAddr: owner,
+			Status:    xbase.ShardStatusOnChain,
+			AssetInfo: &xbase.ShardAssetInfo{
+				AssetCate: int(xbase.AssetCateTicket),
+				AssetExt:  ext,
+			},
+		},
+	}
+}
+
+func TestCheckIn(t *testing.T) {
+	holder := xbase.TestAccount
+	ticket, err := NewTicket(holder, 100, 200, 0)
+	if err != nil {
+		t.Fatalf("new ticket failed.err:%v", err)
+	}
+	payload := ticket.Encode()
+
+	r := newTestRedeemer(holder.Address, "")
+	gate := NewGate(r, nil)
+	result, err := gate.CheckIn(payload)
+	if err != nil {
+		t.Fatalf("check in failed.err:%v", err)
+	}
+	if result.RequestId != "req-1" || len(r.consumed) != 1 {
+		t.Fatalf("consume error.%+v", r.consumed)
+	}
+	c := r.consumed[0]
+	if c.Nonce != ticket.Nonce || c.UAddr != holder.Address || c.UPKey != holder.PublicKey || c.USign != ticket.USign {
+		t.Fatalf("consume param error.%+v", c)
+	}
+
+	// 重放
+	if _, err := gate.CheckIn(payload); err != ErrReplay {
+		t.Fatalf("want ErrReplay, got %v", err)
+	}
+	if len(r.consumed) != 1 {
+		t.Fatal("replay should not consume")
+	}
+}
+
+func TestCheckInConcurrentReplay(t *testing.T) {
+	holder := xbase.TestAccount
+	ticket, _ := NewTicket(holder, 100, 200, 0)
+	r := newTestRedeemer(holder.Address, "")
+	gate := NewGate(r, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			gate.CheckIn(ticket.Encode())
+		}()
+	}
+	wg.Wait()
+	if len(r.consumed) != 1 {
+		t.Fatalf("concurrent check in consumed %d times", len(r.consumed))
+	}
+}
+
+func TestVerifyReject(t *testing.T) {
+	holder, other := xbase.TestAccount, xbase.TestTransAccount
+	ticket, _ := NewTicket(holder, 100, 200, time.Minute)
+
+	// 非持有者
+	gate := NewGate(newTestRedeemer(other.Address, ""), nil)
+	if _, err := gate.Verify(ticket.Encode()); err != ErrNotOwner {
+		t.Fatalf("want ErrNotOwner, got %v", err)
+	}
+
+	// 篡改碎片id
+	gate = NewGate(newTestRedeemer(holder.Address, ""), nil)
+	forged := *ticket
+	forged.ShardId = 201
+	if _, err := gate.Verify(forged.Encode()); err != ErrSignInvalid {
+		t.Fatalf("want ErrSignInvalid, got %v", err)
+	}
+
+	// 使用他人公钥冒充地址
+	forged = *ticket
+	forged.PubKey = other.PublicKey
+	if _, err := gate.Verify(forged.Encode()); err != ErrAddrMismatch {
+		t.Fatalf("want ErrAddrMismatch, got %v", err)
+	}
+
+	// 过期
+	gate.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	if _, err := gate.Verify(ticket.Encode()); err != ErrExpired {
+		t.Fatalf("want ErrExpired, got %v", err)
+	}
+
+	// 有效期过长
+	long, _ := NewTicket(holder, 100, 200, time.Hour)
+	gate.now = time.Now
+	if _, err := gate.Verify(long.Encode()); err != ErrTTLTooLong {
+		t.Fatalf("want ErrTTLTooLong, got %v", err)
+	}
+
+	// 已核销
+	r := newTestRedeemer(holder.Address, "")
+	r.meta.Status = xbase.ShardStatusConsumed
+	if _, err := NewGate(r, nil).Verify(ticket.Encode()); err != ErrConsumed {
+		t.Fatalf("want ErrConsumed, got %v", err)
+	}
+
+	// 非门票资产
+	r = newTestRedeemer(holder.Address, "")
+	r.meta.AssetInfo.AssetCate = int(xbase.AssetCateArt)
+	if _, err := NewGate(r, nil).Verify(ticket.Encode()); err != ErrNotTicket {
+		t.Fatalf("want ErrNotTicket, got %v", err)
+	}
+
+	// 场次已结束
+	ext, _ := json.Marshal(&xbase.TicketExt{Venue: "v", SessionStart: time.Now().Add(-2 * time.Hour).Unix()})
+	if _, err := NewGate(newTestRedeemer(holder.Address, string(ext)), nil).Verify(ticket.Encode()); err != ErrSessionEnded {
+		t.Fatalf("want ErrSessionEnded, got %v", err)
+	}
+
+	if _, err := gate.Verify("not a ticket"); err != ErrPayloadInvalid {
+		t.Fatalf("want ErrPayloadInvalid, got %v", err)
+	}
+}
+
+func TestTicketPNG(t *testing.T) {
+	ticket, _ := NewTicket(xbase.TestAccount, 100, 200, 0)
+	data, err := ticket.PNG(0)
+	if err != nil {
+		t.Fatalf("render png failed.err:%v", err)
+	}
+	if _, err := png.Decode(bytes.NewReader(data)); err != nil {
+		t.Fatalf("decode png failed.err:%v", err)
+	}
+
+	decoded, err := DecodeTicket(ticket.Encode())
+	if err != nil || *decoded != *ticket {
+		t.Fatalf("decode ticket error.%+v err:%v", decoded, err)
+	}
+}
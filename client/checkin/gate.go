@@ -0,0 +1,204 @@
+package checkin
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	xbase "github.com/xuperchain/xasset-sdk-go/client/base"
+	"github.com/xuperchain/xasset-sdk-go/common/nonce"
+)
+
+const (
+	// 默认允许的最长凭证有效期
+	DefaultMaxTTL = 5 * time.Minute
+	// 默认允许的时钟误差
+	DefaultClockSkew = 30 * time.Second
+)
+
+var (
+	ErrNoRedeemer    = errors.New("redeemer unset")
+	ErrExpired       = errors.New("check-in ticket expired")
+	ErrTTLTooLong    = errors.New("check-in ticket ttl exceeds limit")
+	ErrSignInvalid   = errors.New("check-in signature invalid")
+	ErrAddrMismatch  = errors.New("address does not match public key")
+	ErrNotOwner      = errors.New("shard not owned by the holder")
+	ErrNotTicket     = errors.New("asset category not allowed to check in")
+	ErrConsumed      = errors.New("shard already consumed")
+	ErrUnavailable   = errors.New("shard status not available for check in")
+	ErrSessionEnded  = errors.New("ticket session ended")
+	ErrReplay        = errors.New("check-in ticket already used")
+	ErrNoShardMeta   = errors.New("shard meta empty")
+	ErrNoNonceStore  = errors.New("nonce store unset")
+	ErrAssetMismatch = errors.New("shard asset does not match ticket")
+)
+
+// 检票端依赖的接口，*xasset.AssetOper 实现了该接口
+type Redeemer interface {
+	QueryShard(param *xbase.QueryShardParam) (*xbase.QueryShardResp, *xbase.RequestRes, error)
+	ConsumeShard(param *xbase.ConsumeShardParam) (*xbase.BaseResp, *xbase.RequestRes, error)
+}
+
+// 检票结果
+type Result struct {
+	Ticket *Ticket               `json:"ticket"`
+	Shard  *xbase.QueryShardMeta `json:"shard"`
+	// 资产的门票扩展信息，未设置时为空
+	Ext *xbase.TicketExt `json:"ext,omitempty"`
+	// 核销请求id
+	RequestId string `json:"request_id,omitempty"`
+}
+
+// 检票端
+type Gate struct {
+	// 允许核验的资产分类，为空时只允许门票
+	AllowCates []xbase.AssetType
+	// 凭证允许的最长有效期
+	MaxTTL time.Duration
+	// 允许的时钟误差
+	ClockSkew time.Duration
+	// 资产创建者账户，可选
+	CAccount auth.Signer
+
+	redeemer Redeemer
+	store    nonce.Store
+	now      func() time.Time
+}
+
+// NewGate store为空时使用进程内存储，多个检票端共同检票时需传入共享存储
+func NewGate(redeemer Redeemer, store nonce.Store) *Gate {
+	if store == nil {
+		store = nonce.NewMemStore()
+	}
+	return &Gate{
+		MaxTTL:    DefaultMaxTTL,
+		ClockSkew: DefaultClockSkew,
+		redeemer:  redeemer,
+		store:     store,
+		now:       time.Now,
+	}
+}
+
+// Verify 校验凭证签名、有效期和碎片归属，不核销也不占用nonce
+func (t *Gate) Verify(payload string) (*Result, error) {
+	if t.redeemer == nil {
+		return nil, ErrNoRedeemer
+	}
+	ticket, err := DecodeTicket(payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.verifyTicket(ticket); err != nil {
+		return nil, err
+	}
+
+	resp, _, err := t.redeemer.QueryShard(&xbase.QueryShardParam{
+		AssetId: ticket.AssetId,
+		ShardId: ticket.ShardId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	meta := resp.Meta
+	if meta == nil || meta.AssetInfo == nil {
+		return nil, ErrNoShardMeta
+	}
+	if meta.AssetId != ticket.AssetId || meta.ShardId != ticket.ShardId {
+		return nil, ErrAssetMismatch
+	}
+	if meta.OwnerAddr != ticket.Addr {
+		return nil, ErrNotOwner
+	}
+	if !t.allowCate(xbase.AssetType(meta.AssetInfo.AssetCate)) {
+		return nil, ErrNotTicket
+	}
+	if meta.Status.IsConsumed() {
+		return nil, ErrConsumed
+	}
+	if !meta.Status.IsTransferable() {
+		return nil, ErrUnavailable
+	}
+
+	result := &Result{Ticket: ticket, Shard: meta}
+	if ext, err := meta.AssetInfo.ParseAssetExt(); err == nil {
+		if te, ok := ext.(*xbase.TicketExt); ok && te.SessionStart > 0 {
+			if te.Ended(t.now().Add(-t.ClockSkew)) {
+				return nil, ErrSessionEnded
+			}
+			result.Ext = te
+		}
+	}
+	return result, nil
+}
+
+// CheckIn 校验通过后占用nonce并核销碎片，同一凭证只能成功一次
+func (t *Gate) CheckIn(payload string) (*Result, error) {
+	if t.store == nil {
+		return nil, ErrNoNonceStore
+	}
+	result, err := t.Verify(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket := result.Ticket
+	key := fmt.Sprintf("checkin:%s:%d", ticket.Addr, ticket.Nonce)
+	expireAt := time.Unix(ticket.ExpireAt, 0).Add(t.ClockSkew)
+	if !t.store.Use(key, expireAt) {
+		return nil, ErrReplay
+	}
+
+	resp, _, err := t.redeemer.ConsumeShard(&xbase.ConsumeShardParam{
+		AssetId:  ticket.AssetId,
+		ShardId:  ticket.ShardId,
+		Nonce:    ticket.Nonce,
+		UAddr:    ticket.Addr,
+		USign:    ticket.USign,
+		UPKey:    ticket.PubKey,
+		CAccount: t.CAccount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.RequestId = resp.RequestId
+	return result, nil
+}
+
+func (t *Gate) verifyTicket(ticket *Ticket) error {
+	now := t.now()
+	if now.Add(-t.ClockSkew).Unix() > ticket.ExpireAt {
+		return ErrExpired
+	}
+	if t.MaxTTL > 0 && time.Unix(ticket.ExpireAt, 0).Sub(now) > t.MaxTTL+t.ClockSkew {
+		return ErrTTLTooLong
+	}
+
+	pub, err := auth.GetEcdsaPubKeyByJsStr(ticket.PubKey)
+	if err != nil {
+		return ErrPayloadInvalid
+	}
+	if ok, _ := auth.VerifyAddrByPubKey(ticket.Addr, pub); !ok {
+		return ErrAddrMismatch
+	}
+	if ok, _ := auth.XassetVerify(ticket.PubKey, ticket.Sign, ticket.challenge()); !ok {
+		return ErrSignInvalid
+	}
+	consumeMsg := []byte(fmt.Sprintf("%d%d", ticket.AssetId, ticket.Nonce))
+	if ok, _ := auth.XassetVerify(ticket.PubKey, ticket.USign, consumeMsg); !ok {
+		return ErrSignInvalid
+	}
+	return nil
+}
+
+func (t *Gate) allowCate(cate xbase.AssetType) bool {
+	if len(t.AllowCates) == 0 {
+		return cate == xbase.AssetCateTicket
+	}
+	for _, c := range t.AllowCates {
+		if c == cate {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+// Package checkin 门票类资产的二维码核验与核销
+//
+// 持有者使用账户私钥对短时有效的挑战签名并生成二维码，检票端扫码后
+// 校验签名、碎片归属，并通过ConsumeShard核销。
+package checkin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	xbase "github.com/xuperchain/xasset-sdk-go/client/base"
+	"github.com/xuperchain/xasset-sdk-go/common/qrcode"
+	"github.com/xuperchain/xasset-sdk-go/utils"
+)
+
+const (
+	// 二维码内容前缀，用于区分版本
+	PayloadPrefix = "xck1:"
+	// 默认有效期
+	DefaultTTL = 60 * time.Second
+	// 默认二维码模块像素数
+	DefaultQRScale = 6
+)
+
+var (
+	ErrPayloadInvalid = errors.New("check-in payload invalid")
+	ErrTTLInvalid     = errors.New("check-in ttl invalid, must be positive")
+)
+
+// 检票凭证，即二维码中携带的内容
+// Sign 对检票挑战的签名，USign 对asset_id+nonce的签名，用于核销
+type Ticket struct {
+	AssetId  int64  `json:"a"`
+	ShardId  int64  `json:"s"`
+	Addr     string `json:"u"`
+	PubKey   string `json:"k"`
+	Nonce    int64  `json:"n"`
+	ExpireAt int64  `json:"e"`
+	Sign     string `json:"cs"`
+	USign    string `json:"us"`
+}
+
+// NewTicket 持有者生成检票凭证，ttl为0时使用DefaultTTL
+func NewTicket(account auth.Signer, assetId, shardId int64, ttl time.Duration) (*Ticket, error) {
+	if err := xbase.AccountValid(account); err != nil {
+		return nil, err
+	}
+	if err := xbase.AssetIdValid(assetId); err != nil {
+		return nil, err
+	}
+	if err := xbase.ShardIdValid(shardId); err != nil {
+		return nil, err
+	}
+	if ttl < 0 {
+		return nil, ErrTTLInvalid
+	}
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+
+	t := &Ticket{
+		AssetId:  assetId,
+		ShardId:  shardId,
+		Addr:     account.GetAddress(),
+		PubKey:   account.GetPublicKey(),
+		Nonce:    utils.GenNonce(),
+		ExpireAt: time.Now().Add(ttl).Unix(),
+	}
+	var err error
+	t.Sign, err = account.Sign(t.challenge())
+	if err != nil {
+		return nil, err
+	}
+	t.USign, err = account.Sign([]byte(fmt.Sprintf("%d%d", assetId, t.Nonce)))
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// 检票挑战原文，覆盖碎片、地址、nonce和过期时间
+func (t *Ticket) challenge() []byte {
+	return []byte(fmt.Sprintf("xasset-checkin:%d:%d:%s:%d:%d",
+		t.AssetId, t.ShardId, t.Addr, t.Nonce, t.ExpireAt))
+}
+
+// Encode 序列化为二维码内容
+func (t *Ticket) Encode() string {
+	data, _ := json.Marshal(t)
+	return PayloadPrefix + string(data)
+}
+
+// DecodeTicket 解析二维码内容，不做签名校验
+func DecodeTicket(payload string) (*Ticket, error) {
+	payload = strings.TrimSpace(payload)
+	if !strings.HasPrefix(payload, PayloadPrefix) {
+		return nil, ErrPayloadInvalid
+	}
+	var t Ticket
+	if err := json.Unmarshal([]byte(payload[len(PayloadPrefix):]), &t); err != nil {
+		return nil, ErrPayloadInvalid
+	}
+	if t.AssetId <= 0 || t.ShardId <= 0 || t.Nonce <= 0 || t.Addr == "" ||
+		t.PubKey == "" || t.Sign == "" || t.USign == "" {
+		return nil, ErrPayloadInvalid
+	}
+	return &t, nil
+}
+
+// QRCode 编码为二维码
+func (t *Ticket) QRCode() (*qrcode.Code, error) {
+	return qrcode.EncodeString(t.Encode(), qrcode.LevelM)
+}
+
+// PNG 渲染二维码图片，scale为0时使用DefaultQRScale
+func (t *Ticket) PNG(scale int) ([]byte, error) {
+	code, err := t.QRCode()
+	if err != nil {
+		return nil, err
+	}
+	if scale <= 0 {
+		scale = DefaultQRScale
+	}
+	return code.PNG(scale)
+}
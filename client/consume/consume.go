@@ -0,0 +1,192 @@
+// Package consume 核销签名的生成与服务端预校验
+//
+// 终端用户在设备上对asset_id+nonce签名，业务服务端转发ConsumeShard请求前先在本地
+// 校验签名、公钥与地址匹配关系以及nonce是否新鲜且未使用，避免无效请求打到平台。
+package consume
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	xbase "github.com/xuperchain/xasset-sdk-go/client/base"
+	"github.com/xuperchain/xasset-sdk-go/common/nonce"
+)
+
+const (
+	// 默认nonce最大有效时长
+	DefaultMaxAge = 10 * time.Minute
+	// 默认允许的时钟误差
+	DefaultClockSkew = time.Minute
+	// 不校验新鲜度时已用nonce的保留时长
+	DefaultRetention = 24 * time.Hour
+
+	// nonce低位随机数比特数，高位为秒级时间戳
+	nonceRandBits = 28
+)
+
+var (
+	ErrNoPubKey     = errors.New("user public key unset")
+	ErrPubKeyFormat = errors.New("user public key invalid")
+	ErrAddrMismatch = errors.New("user address does not match public key")
+	ErrSignInvalid  = errors.New("user sign invalid")
+	ErrNonceStale   = errors.New("nonce expired")
+	ErrNonceFuture  = errors.New("nonce issued in the future")
+	ErrNonceUsed    = errors.New("nonce already used")
+	ErrNoConsumer   = errors.New("consumer unset")
+)
+
+// 核销接口，*xasset.AssetOper 实现了该接口
+type Consumer interface {
+	ConsumeShard(param *xbase.ConsumeShardParam) (*xbase.BaseResp, *xbase.RequestRes, error)
+}
+
+// NewNonce 生成带时间戳的nonce，高位为秒级时间戳，低位为随机数
+func NewNonce() (int64, error) {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return 0, err
+	}
+	r := int64(binary.BigEndian.Uint64(buf[:]) & (1<<nonceRandBits - 1))
+	return time.Now().Unix()<<nonceRandBits | r, nil
+}
+
+// NonceTime 解析NewNonce生成的nonce中的时间
+func NonceTime(n int64) time.Time {
+	return time.Unix(n>>nonceRandBits, 0)
+}
+
+// Sign 终端用户生成核销参数，返回的参数可直接提交给业务服务端
+func Sign(account auth.Signer, assetId, shardId int64) (*xbase.ConsumeShardParam, error) {
+	if err := xbase.AccountValid(account); err != nil {
+		return nil, err
+	}
+	n, err := NewNonce()
+	if err != nil {
+		return nil, err
+	}
+	sign, err := account.Sign(signMsg(assetId, n))
+	if err != nil {
+		return nil, xbase.ComErrAccountSignFailed
+	}
+	param := &xbase.ConsumeShardParam{
+		AssetId: assetId,
+		ShardId: shardId,
+		Nonce:   n,
+		UAddr:   account.GetAddress(),
+		USign:   sign,
+		UPKey:   account.GetPublicKey(),
+	}
+	if err := param.Valid(); err != nil {
+		return nil, err
+	}
+	return param, nil
+}
+
+// 服务端核销签名校验
+type Verifier struct {
+	// nonce最大有效时长，为0时不校验新鲜度，仅校验是否重复
+	MaxAge time.Duration
+	// 允许的时钟误差
+	ClockSkew time.Duration
+	// 不校验新鲜度时已用nonce的保留时长
+	Retention time.Duration
+
+	store nonce.Store
+	now   func() time.Time
+}
+
+// NewVerifier store为空时使用进程内存储，多实例部署时需传入共享存储
+func NewVerifier(store nonce.Store) *Verifier {
+	if store == nil {
+		store = nonce.NewMemStore()
+	}
+	return &Verifier{
+		MaxAge:    DefaultMaxAge,
+		ClockSkew: DefaultClockSkew,
+		Retention: DefaultRetention,
+		store:     store,
+		now:       time.Now,
+	}
+}
+
+// Verify 校验核销参数，通过后nonce被占用
+func (t *Verifier) Verify(param *xbase.ConsumeShardParam) error {
+	if err := t.Check(param); err != nil {
+		return err
+	}
+	if !t.store.Use(fmt.Sprintf("consume:%s:%d", param.UAddr, param.Nonce), t.retainUntil(param.Nonce)) {
+		return ErrNonceUsed
+	}
+	return nil
+}
+
+// Check 只校验签名和新鲜度，不占用nonce
+func (t *Verifier) Check(param *xbase.ConsumeShardParam) error {
+	if err := param.Valid(); err != nil {
+		return err
+	}
+	if param.UPKey == "" {
+		return ErrNoPubKey
+	}
+	if t.MaxAge > 0 {
+		issued := NonceTime(param.Nonce)
+		now := t.now()
+		if issued.After(now.Add(t.ClockSkew)) {
+			return ErrNonceFuture
+		}
+		if now.Sub(issued) > t.MaxAge+t.ClockSkew {
+			return ErrNonceStale
+		}
+	}
+
+	pub, err := auth.GetEcdsaPubKeyByJsStr(param.UPKey)
+	if err != nil {
+		return ErrPubKeyFormat
+	}
+	addr, err := auth.GetAddrByPubKey(pub)
+	if err != nil || addr != param.UAddr {
+		return ErrAddrMismatch
+	}
+	if ok, _ := auth.XassetVerify(param.UPKey, param.USign, signMsg(param.AssetId, param.Nonce)); !ok {
+		return ErrSignInvalid
+	}
+	return nil
+}
+
+func (t *Verifier) retainUntil(n int64) time.Time {
+	if t.MaxAge > 0 {
+		return NonceTime(n).Add(t.MaxAge + t.ClockSkew*2)
+	}
+	return t.now().Add(t.Retention)
+}
+
+// Guard 校验通过后再调用ConsumeShard，可替代原Consumer使用
+type Guard struct {
+	verifier *Verifier
+	consumer Consumer
+}
+
+func NewGuard(consumer Consumer, verifier *Verifier) *Guard {
+	if verifier == nil {
+		verifier = NewVerifier(nil)
+	}
+	return &Guard{verifier: verifier, consumer: consumer}
+}
+
+func (t *Guard) ConsumeShard(param *xbase.ConsumeShardParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	if t.consumer == nil {
+		return nil, nil, ErrNoConsumer
+	}
+	if err := t.verifier.Verify(param); err != nil {
+		return nil, nil, err
+	}
+	return t.consumer.ConsumeShard(param)
+}
+
+func signMsg(assetId, n int64) []byte {
+	return []byte(fmt.Sprintf("%d%d", assetId, n))
+}
@@ -0,0 +1,117 @@
+package consume
+
+import (
+	"testing"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	xbase "github.com/xuperchain/xasset-sdk-go/client/base"
+)
+
+type testConsumer struct {
+	params []*xbase.ConsumeShardParam
+}
+
+func (t *testConsumer) ConsumeShard(param *xbase.ConsumeShardParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	t.params = append(t.params, param)
+	return &xbase.BaseResp{}, nil, nil
+}
+
+func TestNonce(t *testing.T) {
+	n, err := NewNonce()
+	if err != nil || n <= 0 {
+		t.Fatalf("new nonce error.%d err:%v", n, err)
+	}
+	if d := time.Since(NonceTime(n)); d < 0 || d > 2*time.Second {
+		t.Fatalf("nonce time error.%v", NonceTime(n))
+	}
+}
+
+func TestGuard(t *testing.T) {
+	user := xbase.TestAccount
+	param, err := Sign(user, 100, 200)
+	if err != nil {
+		t.Fatalf("sign failed.err:%v", err)
+	}
+	if ok, _ := auth.XassetVerifyECDSA(user.PublicKey, param.USign, signMsg(100, param.Nonce)); !ok {
+		t.Fatal("sign should verify")
+	}
+
+	c := &testConsumer{}
+	g := NewGuard(c, nil)
+	if _, _, err := g.ConsumeShard(param); err != nil {
+		t.Fatalf("consume failed.err:%v", err)
+	}
+	if _, _, err := g.ConsumeShard(param); err != ErrNonceUsed {
+		t.Fatalf("want ErrNonceUsed, got %v", err)
+	}
+	if len(c.params) != 1 {
+		t.Fatalf("consume count error.%d", len(c.params))
+	}
+}
+
+func TestVerifyReject(t *testing.T) {
+	user, other := xbase.TestAccount, xbase.TestTransAccount
+	v := NewVerifier(nil)
+
+	param, _ := Sign(user, 100, 200)
+	bad := *param
+	bad.AssetId = 101
+	if err := v.Verify(&bad); err != ErrSignInvalid {
+		t.Fatalf("want ErrSignInvalid, got %v", err)
+	}
+
+	bad = *param
+	bad.UPKey = other.PublicKey
+	if err := v.Verify(&bad); err != ErrAddrMismatch {
+		t.Fatalf("want ErrAddrMismatch, got %v", err)
+	}
+
+	bad = *param
+	bad.UPKey = ""
+	if err := v.Verify(&bad); err != ErrNoPubKey {
+		t.Fatalf("want ErrNoPubKey, got %v", err)
+	}
+
+	v.now = func() time.Time { return time.Now().Add(time.Hour) }
+	if err := v.Verify(param); err != ErrNonceStale {
+		t.Fatalf("want ErrNonceStale, got %v", err)
+	}
+	v.now = func() time.Time { return time.Now().Add(-time.Hour) }
+	if err := v.Verify(param); err != ErrNonceFuture {
+		t.Fatalf("want ErrNonceFuture, got %v", err)
+	}
+
+	// 关闭新鲜度校验后兼容任意nonce
+	v.now = time.Now
+	v.MaxAge = 0
+	sign, _ := auth.XassetSignECDSA(user.PrivateKey, signMsg(100, 12345))
+	legacy := &xbase.ConsumeShardParam{AssetId: 100, ShardId: 200, Nonce: 12345, UAddr: user.Address, USign: sign, UPKey: user.PublicKey}
+	if err := v.Verify(legacy); err != nil {
+		t.Fatalf("verify legacy nonce failed.err:%v", err)
+	}
+	if err := v.Verify(legacy); err != ErrNonceUsed {
+		t.Fatalf("want ErrNonceUsed, got %v", err)
+	}
+}
+
+func TestVerifyGmAccount(t *testing.T) {
+	user, err := auth.NewXchainGmAccount(auth.MnemStrgthWeak, auth.MnemLangEN)
+	if err != nil {
+		t.Fatalf("create gm account failed.err:%v", err)
+	}
+	param, err := Sign(user, 100, 200)
+	if err != nil {
+		t.Fatalf("sign failed.err:%v", err)
+	}
+	v := NewVerifier(nil)
+	if err := v.Verify(param); err != nil {
+		t.Fatalf("verify gm sign failed.err:%v", err)
+	}
+
+	bad := *param
+	bad.UPKey = xbase.TestAccount.PublicKey
+	if err := v.Verify(&bad); err != ErrAddrMismatch {
+		t.Fatalf("want ErrAddrMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package evidence
+
+import (
+	"bytes"
+	"html/template"
+	"time"
+)
+
+var checkNames = map[string]string{
+	"file_hash":   "文件哈希",
+	"asset_id":    "资产ID",
+	"asset_cate":  "资产分类",
+	"title":       "资产名称",
+	"short_desc":  "资产简介",
+	"create_addr": "创建者地址",
+	"tx_id":       "上链交易ID",
+	"thumb":       "缩略图",
+}
+
+var certTpl = template.Must(template.New("cert").Funcs(template.FuncMap{
+	"fmtTime": func(ts int64) string {
+		if ts <= 0 {
+			return "-"
+		}
+		return time.Unix(ts, 0).Format("2006-01-02 15:04:05 MST")
+	},
+	"checkName": func(name string) string {
+		if v, ok := checkNames[name]; ok {
+			return v
+		}
+		return name
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+<meta charset="utf-8">
+<title>数字资产存证校验证书 - {{.AssetId}}</title>
+<style>
+body{font-family:"PingFang SC","Microsoft YaHei",sans-serif;margin:40px;color:#222}
+.cert{border:3px double #555;padding:32px;max-width:880px;margin:auto}
+h1{text-align:center;letter-spacing:4px}
+table{width:100%;border-collapse:collapse;margin-top:16px}
+th,td{border:1px solid #bbb;padding:6px 10px;text-align:left;word-break:break-all;font-size:13px}
+th{background:#f3f3f3;width:160px}
+.pass{color:#1a7f37;font-weight:bold}
+.fail{color:#cf222e;font-weight:bold}
+.result{text-align:center;font-size:20px;margin:24px 0}
+.foot{margin-top:24px;font-size:12px;color:#666}
+</style>
+</head>
+<body>
+<div class="cert">
+<h1>数字资产存证校验证书</h1>
+<div class="result">校验结论：{{if .Passed}}<span class="pass">一致</span>{{else}}<span class="fail">不一致</span>{{end}}</div>
+<table>
+<tr><th>资产ID</th><td>{{.AssetId}}</td></tr>
+<tr><th>资产名称</th><td>{{.Title}}</td></tr>
+<tr><th>创建者地址</th><td>{{.CreateAddr}}</td></tr>
+<tr><th>上链交易ID</th><td>{{.TxId}}</td></tr>
+<tr><th>国家授时证书编号</th><td>{{if .GhCertId}}{{.GhCertId}}{{else}}-{{end}}</td></tr>
+<tr><th>存证时间</th><td>{{fmtTime .EvidenceAt}}</td></tr>
+<tr><th>存证文件哈希</th><td>{{.FileHash}}</td></tr>
+<tr><th>原始文件哈希({{.HashAlgo}})</th><td>{{.ActualHash}}</td></tr>
+<tr><th>原始文件大小</th><td>{{.FileSize}} 字节</td></tr>
+</table>
+<table>
+<tr><th>校验项</th><th>存证值</th><th>比对值</th><th>结果</th></tr>
+{{range .Checks}}<tr><td>{{checkName .Name}}</td><td>{{.Expected}}</td><td>{{.Actual}}</td><td>{{if .Pass}}<span class="pass">通过</span>{{else}}<span class="fail">不通过</span>{{end}}</td></tr>
+{{end}}</table>
+<div class="foot">校验时间：{{fmtTime .VerifiedAt}}。本证书由xasset-sdk-go根据链上存证信息与原始文件离线计算生成。</div>
+</div>
+</body>
+</html>
+`))
+
+// HTML 输出可读的校验证书
+func (t *Report) HTML() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := certTpl.Execute(&buf, t); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
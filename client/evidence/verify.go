@@ -0,0 +1,296 @@
+package evidence
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	xbase "github.com/xuperchain/xasset-sdk-go/client/base"
+)
+
+// 支持的文件哈希算法
+const (
+	HashSha256 = "sha256"
+	HashMd5    = "md5"
+	HashSha1   = "sha1"
+)
+
+// 上传文件返回的存储链接前缀
+const bosLinkPrefix = "bos_v1://"
+
+var hashFactory = map[string]func() hash.Hash{
+	HashSha256: sha256.New,
+	HashMd5:    md5.New,
+	HashSha1:   sha1.New,
+}
+
+var (
+	ErrNoFile     = errors.New("original file unset")
+	ErrNoQuerier  = errors.New("querier unset")
+	ErrHashAlgo   = errors.New("unsupported hash algorithm")
+	ErrNoEvidence = errors.New("asset has no evidence info")
+)
+
+// 查询接口，*xasset.AssetOper 实现了该接口
+type Querier interface {
+	GetEvidenceInfo(param *xbase.GetEvidenceInfoParam) (*xbase.GetEvidenceInfoResp, *xbase.RequestRes, error)
+	QueryAsset(param *xbase.QueryAssetParam) (*xbase.QueryAssetResp, *xbase.RequestRes, error)
+}
+
+// 校验参数
+// FilePath 原始文件路径
+// DataByte 原始文件二进制串
+// HashAlgos 可选，尝试的哈希算法，为空时依次尝试sha256、md5、sha1
+// 注意：文件路径和二进制串为二选一
+type VerifyParam struct {
+	AssetId   int64
+	FilePath  string
+	DataByte  []byte
+	HashAlgos []string
+}
+
+func (t *VerifyParam) Valid() error {
+	if t == nil {
+		return xbase.ErrNilPointer
+	}
+	if err := xbase.AssetIdValid(t.AssetId); err != nil {
+		return err
+	}
+	if t.FilePath == "" && t.DataByte == nil {
+		return ErrNoFile
+	}
+	for _, algo := range t.HashAlgos {
+		if _, ok := hashFactory[algo]; !ok {
+			return ErrHashAlgo
+		}
+	}
+	return nil
+}
+
+// 单项校验结果
+type CheckItem struct {
+	Name     string `json:"name"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+	Pass     bool   `json:"pass"`
+}
+
+// 存证校验报告
+type Report struct {
+	AssetId    int64        `json:"asset_id"`
+	Title      string       `json:"title"`
+	CreateAddr string       `json:"create_addr"`
+	TxId       string       `json:"tx_id"`
+	GhCertId   string       `json:"gh_cert_id"`
+	EvidenceAt int64        `json:"evidence_ctime"`
+	FileHash   string       `json:"file_hash"`
+	ActualHash string       `json:"actual_hash"`
+	HashAlgo   string       `json:"hash_algo"`
+	FileSize   int64        `json:"file_size"`
+	Checks     []*CheckItem `json:"checks"`
+	Passed     bool         `json:"passed"`
+	VerifiedAt int64        `json:"verified_at"`
+}
+
+// 未通过的校验项
+func (t *Report) Failed() []*CheckItem {
+	var items []*CheckItem
+	for _, c := range t.Checks {
+		if !c.Pass {
+			items = append(items, c)
+		}
+	}
+	return items
+}
+
+// JSON 格式化输出报告
+func (t *Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(t, "", "  ")
+}
+
+// Verifier 离线校验资产存证信息
+type Verifier struct {
+	querier Querier
+	now     func() time.Time
+}
+
+func NewVerifier(querier Querier) *Verifier {
+	return &Verifier{
+		querier: querier,
+		now:     time.Now,
+	}
+}
+
+// Verify 重新计算原始文件哈希并与存证信息、资产信息逐项比对
+// 只有请求失败时返回error，比对不一致体现在Report.Passed中
+func (t *Verifier) Verify(param *VerifyParam) (*Report, error) {
+	if err := param.Valid(); err != nil {
+		return nil, err
+	}
+	if t.querier == nil {
+		return nil, ErrNoQuerier
+	}
+
+	evi, _, err := t.querier.GetEvidenceInfo(&xbase.GetEvidenceInfoParam{AssetId: param.AssetId})
+	if err != nil {
+		return nil, err
+	}
+	ast, _, err := t.querier.QueryAsset(&xbase.QueryAssetParam{AssetId: param.AssetId})
+	if err != nil {
+		return nil, err
+	}
+	if evi.AssetInfo == nil || ast.Meta == nil {
+		return nil, ErrNoEvidence
+	}
+
+	algos := param.HashAlgos
+	if len(algos) == 0 {
+		algos = []string{HashSha256, HashMd5, HashSha1}
+	}
+	sums, size, err := sumFile(param, algos)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		AssetId:    param.AssetId,
+		Title:      ast.Meta.Title,
+		CreateAddr: evi.CreateAddr,
+		TxId:       evi.TxId,
+		GhCertId:   evi.GhCertId,
+		EvidenceAt: evi.Ctime,
+		FileHash:   evi.FileHash,
+		FileSize:   size,
+		VerifiedAt: t.now().Unix(),
+	}
+
+	// 1.文件哈希
+	hashItem := &CheckItem{Name: "file_hash", Expected: evi.FileHash}
+	for _, algo := range algos {
+		if evi.FileHash != "" && strings.EqualFold(sums[algo], evi.FileHash) {
+			hashItem.Pass = true
+			report.HashAlgo = algo
+			break
+		}
+	}
+	if report.HashAlgo == "" {
+		report.HashAlgo = algos[0]
+	}
+	report.ActualHash = sums[report.HashAlgo]
+	hashItem.Actual = report.ActualHash
+	report.Checks = append(report.Checks, hashItem)
+
+	// 2.存证信息与链上资产信息
+	info, meta := evi.AssetInfo, ast.Meta
+	report.Checks = append(report.Checks,
+		checkItem("asset_id", fmt.Sprintf("%d", info.AssetId), fmt.Sprintf("%d", meta.AssetId)),
+		checkItem("asset_cate", fmt.Sprintf("%d", info.AssetCate), fmt.Sprintf("%d", meta.AssetCate)),
+		checkItem("title", info.Title, meta.Title),
+		checkItem("short_desc", info.ShortDesc, meta.ShortDesc),
+		checkItem("create_addr", evi.CreateAddr, meta.CreateAddr),
+		checkNormItem("tx_id", evi.TxId, meta.TxId, normTxId),
+		checkNormItem("thumb", thumbKey(info.Thumb), thumbKey(meta.Thumb), nil),
+	)
+
+	report.Passed = len(report.Failed()) == 0
+	return report, nil
+}
+
+func checkItem(name, expected, actual string) *CheckItem {
+	return &CheckItem{
+		Name:     name,
+		Expected: expected,
+		Actual:   actual,
+		Pass:     expected == actual,
+	}
+}
+
+// 报告中展示原值，按归一化后的值比对
+func checkNormItem(name, expected, actual string, norm func(string) string) *CheckItem {
+	item := checkItem(name, expected, actual)
+	if norm != nil {
+		item.Pass = norm(expected) == norm(actual)
+	}
+	return item
+}
+
+// tx_id为十六进制串，忽略大小写
+func normTxId(txId string) string {
+	return strings.ToLower(strings.TrimSpace(txId))
+}
+
+// 缩略图比对只关心链接集合，链接归一化为对象路径
+func thumbKey(thumbs []xbase.ThumbMap) string {
+	var urls []string
+	for _, th := range thumbs {
+		for k, u := range th.Urls {
+			urls = append(urls, strings.ToLower(strings.TrimSpace(k))+"="+thumbPath(u))
+		}
+	}
+	sort.Strings(urls)
+	return strings.Join(urls, ",")
+}
+
+// 存证中可能为存储链接(bos_v1://bucket/key/property)，查询结果可能为带签名参数的访问地址，
+// 两者都只保留对象路径，忽略协议、域名、查询参数和存储链接末尾的property
+func thumbPath(link string) string {
+	link = strings.TrimSpace(link)
+	// 协议含下划线，url.Parse无法解析
+	if strings.HasPrefix(link, bosLinkPrefix) {
+		obj := strings.TrimPrefix(link, bosLinkPrefix)
+		if i := strings.Index(obj, "/"); i >= 0 {
+			return path.Dir(obj[i:])
+		}
+		return link
+	}
+	u, err := url.Parse(link)
+	if err != nil || u.Scheme == "" {
+		return link
+	}
+	return u.Path
+}
+
+func sumFile(param *VerifyParam, algos []string) (map[string]string, int64, error) {
+	hs := make(map[string]hash.Hash, len(algos))
+	ws := make([]io.Writer, 0, len(algos))
+	for _, algo := range algos {
+		h := hashFactory[algo]()
+		hs[algo] = h
+		ws = append(ws, h)
+	}
+	w := io.MultiWriter(ws...)
+
+	var size int64
+	if param.FilePath != "" {
+		f, err := os.Open(param.FilePath)
+		if err != nil {
+			return nil, 0, err
+		}
+		defer f.Close()
+		size, err = io.Copy(w, f)
+		if err != nil {
+			return nil, 0, err
+		}
+	} else {
+		n, _ := w.Write(param.DataByte)
+		size = int64(n)
+	}
+
+	sums := make(map[string]string, len(hs))
+	for algo, h := range hs {
+		sums[algo] = hex.EncodeToString(h.Sum(nil))
+	}
+	return sums, size, nil
+}
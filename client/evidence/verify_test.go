@@ -0,0 +1,127 @@
+package evidence
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	xbase "github.com/xuperchain/xasset-sdk-go/client/base"
+)
+
+type testQuerier struct {
+	evi  *xbase.GetEvidenceInfoResp
+	meta *xbase.QueryAssetMeta
+}
+
+func (t *testQuerier) GetEvidenceInfo(param *xbase.GetEvidenceInfoParam) (*xbase.GetEvidenceInfoResp, *xbase.RequestRes, error) {
+	return t.evi, nil, nil
+}
+
+func (t *testQuerier) QueryAsset(param *xbase.QueryAssetParam) (*xbase.QueryAssetResp, *xbase.RequestRes, error) {
+	return &xbase.QueryAssetResp{Meta: t.meta}, nil, nil
+}
+
+func newTestQuerier(file []byte) *testQuerier {
+	sum := md5.Sum(file)
+	thumb := []xbase.ThumbMap{{Urls: map[string]string{"icon": "https://x/icon.jpg"}, Width: "100", Height: "100"}}
+	return &testQuerier{
+		evi: &xbase.GetEvidenceInfoResp{
+			CreateAddr: "TeyyPLpp9L7QAcxHangtcHTu7HUZ6iydY",
+			TxId:       "5a1b2c",
+			FileHash:   hex.EncodeToString(sum[:]),
+			GhCertId:   "GH-001",
+			Ctime:      1650000000,
+			AssetInfo: &xbase.HoraeAssetObject{
+				AssetId:   123,
+				AssetCate: 1,
+				Title:     "<画作>",
+				Thumb:     thumb,
+				ShortDesc: "desc",
+			},
+		},
+		meta: &xbase.QueryAssetMeta{
+			AssetId:    123,
+			AssetCate:  1,
+			Title:      "<画作>",
+			Thumb:      thumb,
+			ShortDesc:  "desc",
+			CreateAddr: "TeyyPLpp9L7QAcxHangtcHTu7HUZ6iydY",
+			TxId:       "5a1b2c",
+		},
+	}
+}
+
+func TestVerify(t *testing.T) {
+	file := []byte("original artwork bytes")
+	q := newTestQuerier(file)
+	v := NewVerifier(q)
+
+	report, err := v.Verify(&VerifyParam{AssetId: 123, DataByte: file})
+	if err != nil {
+		t.Fatalf("verify failed.err:%v", err)
+	}
+	if !report.Passed || report.HashAlgo != HashMd5 {
+		t.Fatalf("verify should pass.report:%+v failed:%v", report, report.Failed())
+	}
+
+	js, err := report.JSON()
+	if err != nil {
+		t.Fatalf("render json failed.err:%v", err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(js, &decoded); err != nil || decoded.AssetId != 123 {
+		t.Fatalf("decode json failed.err:%v", err)
+	}
+
+	html, err := report.HTML()
+	if err != nil {
+		t.Fatalf("render html failed.err:%v", err)
+	}
+	if !bytes.Contains(html, []byte("GH-001")) || bytes.Contains(html, []byte("<画作>")) {
+		t.Fatalf("html content error.%s", html)
+	}
+
+	// tx_id大小写不同、缩略图为存储链接与访问地址，视为一致
+	q.evi.TxId = "5A1B2C"
+	q.evi.AssetInfo.Thumb = []xbase.ThumbMap{{Urls: map[string]string{"icon": "bos_v1://bucket/path/icon.jpg/100_100"}}}
+	q.meta.Thumb = []xbase.ThumbMap{{Urls: map[string]string{"ICON": "https://bucket.bj.bcebos.com/path/icon.jpg?authorization=x"}}}
+	report, err = v.Verify(&VerifyParam{AssetId: 123, DataByte: file})
+	if err != nil || !report.Passed {
+		t.Fatalf("normalized fields should match.err:%v failed:%v", err, report.Failed())
+	}
+	if report.Checks[6].Expected != "5A1B2C" {
+		t.Fatalf("report should keep the raw tx_id.%+v", report.Checks[6])
+	}
+	q.meta.Thumb = []xbase.ThumbMap{{Urls: map[string]string{"icon": "https://bucket.bj.bcebos.com/path/other.jpg"}}}
+	report, _ = v.Verify(&VerifyParam{AssetId: 123, DataByte: file})
+	if report.Passed {
+		t.Fatal("different thumb should not match")
+	}
+	q.meta.Thumb = q.evi.AssetInfo.Thumb
+
+	// 文件被篡改、资产信息不一致
+	q.meta.Title = "另一幅画"
+	report, err = v.Verify(&VerifyParam{AssetId: 123, DataByte: []byte("tampered")})
+	if err != nil {
+		t.Fatalf("verify failed.err:%v", err)
+	}
+	if report.Passed || len(report.Failed()) != 2 {
+		t.Fatalf("verify should fail.failed:%v", report.Failed())
+	}
+	html, _ = report.HTML()
+	if !strings.Contains(string(html), "不一致") {
+		t.Fatal("html should show mismatch")
+	}
+}
+
+func TestVerifyParamValid(t *testing.T) {
+	if err := (&VerifyParam{AssetId: 1}).Valid(); err != ErrNoFile {
+		t.Fatalf("want ErrNoFile, got %v", err)
+	}
+	if err := (&VerifyParam{AssetId: 1, DataByte: []byte{}, HashAlgos: []string{"crc32"}}).Valid(); err != ErrHashAlgo {
+		t.Fatalf("want ErrHashAlgo, got %v", err)
+	}
+}
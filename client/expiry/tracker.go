@@ -0,0 +1,369 @@
+// Package expiry 碎片过期跟踪，用于券、门票的到期提醒和过期处理
+package expiry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	xbase "github.com/xuperchain/xasset-sdk-go/client/base"
+	"github.com/xuperchain/xasset-sdk-go/utils"
+)
+
+// 默认提醒窗口
+const DefaultWindow = 24 * time.Hour
+
+var (
+	ErrNoClient  = errors.New("client unset")
+	ErrNoHolder  = errors.New("holder account unset or not match the address")
+	ErrNoCreator = errors.New("creator account unset")
+)
+
+// 跟踪器依赖的接口，*xasset.AssetOper 实现了该接口
+type Client interface {
+	ListShardsByAddr(param *xbase.ListShardsByAddrParam) (*xbase.ListShardsByAddrResp, *xbase.RequestRes, error)
+	ConsumeShard(param *xbase.ConsumeShardParam) (*xbase.BaseResp, *xbase.RequestRes, error)
+	FreezeShard(param *xbase.LockOrFreezeShardParam) (*xbase.BaseResp, *xbase.RequestRes, error)
+}
+
+// 过期后的处理方式
+type Action int
+
+const (
+	// 仅通知
+	ActionNone Action = iota
+	// 使用持有者账户核销
+	ActionConsume
+	// 使用创建者账户冻结
+	ActionFreeze
+)
+
+// 事件类型
+type EventType int
+
+const (
+	// 将在提醒窗口内过期
+	EventExpiring EventType = iota + 1
+	// 已过期
+	EventExpired
+	// 过期后已核销
+	EventConsumed
+	// 过期后已冻结
+	EventFrozen
+	// 过期处理失败
+	EventActionFailed
+)
+
+var eventNames = map[EventType]string{
+	EventExpiring:     "expiring",
+	EventExpired:      "expired",
+	EventConsumed:     "consumed",
+	EventFrozen:       "frozen",
+	EventActionFailed: "action_failed",
+}
+
+func (t EventType) String() string {
+	if name, ok := eventNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", int(t))
+}
+
+// 过期事件
+// Remaining 距离过期的剩余时间，已过期时为负数
+type Event struct {
+	Type      EventType             `json:"type"`
+	Addr      string                `json:"addr"`
+	Shard     *xbase.QueryShardMeta `json:"shard"`
+	ExpireAt  time.Time             `json:"expire_at"`
+	Remaining time.Duration         `json:"remaining"`
+	Err       error                 `json:"-"`
+}
+
+// 处理策略
+type Policy struct {
+	// 提醒窗口，为0时使用DefaultWindow
+	Window time.Duration
+	// 过期后的处理方式
+	OnExpired Action
+	// 持有者账户，ActionConsume时必填，且需与扫描地址一致
+	Holder auth.Signer
+	// 资产创建者账户，ActionFreeze时必填
+	Creator auth.Signer
+	// 只处理这些分类的资产，为空时处理全部
+	Cates []xbase.AssetType
+}
+
+func (p *Policy) Valid() error {
+	switch p.OnExpired {
+	case ActionNone:
+	case ActionConsume:
+		if err := xbase.AccountValid(p.Holder); err != nil {
+			return ErrNoHolder
+		}
+	case ActionFreeze:
+		if err := xbase.AccountValid(p.Creator); err != nil {
+			return ErrNoCreator
+		}
+	default:
+		return xbase.ErrParamInvalid
+	}
+	if p.Window < 0 {
+		return xbase.ErrParamInvalid
+	}
+	return nil
+}
+
+// 单次扫描结果
+type ScanResult struct {
+	Addr     string `json:"addr"`
+	Scanned  int    `json:"scanned"`
+	Expiring int    `json:"expiring"`
+	Expired  int    `json:"expired"`
+	Consumed int    `json:"consumed"`
+	Frozen   int    `json:"frozen"`
+	Failed   int    `json:"failed"`
+}
+
+// Tracker 扫描地址下的碎片并发出过期事件
+// 同一碎片的同类事件只发出一次
+type Tracker struct {
+	Policy Policy
+	// 每页拉取数量，不超过xbase.MaxLimit
+	PageLimit int
+
+	client  Client
+	mu      sync.Mutex
+	handler func(Event)
+	events  chan Event
+	// 已通知记录，key为 asset_id:shard_id
+	notified map[string]EventType
+	now      func() time.Time
+}
+
+func NewTracker(client Client, policy Policy) *Tracker {
+	return &Tracker{
+		Policy:    policy,
+		PageLimit: xbase.MaxLimit,
+		client:    client,
+		notified:  make(map[string]EventType),
+		now:       time.Now,
+	}
+}
+
+// OnEvent 设置事件回调，在扫描协程中同步调用
+func (t *Tracker) OnEvent(fn func(Event)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.handler = fn
+}
+
+// Events 返回事件通道，需持续读取，否则扫描会阻塞，Run在ctx结束时退出阻塞
+func (t *Tracker) Events(buffer int) <-chan Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.events == nil {
+		t.events = make(chan Event, buffer)
+	}
+	return t.events
+}
+
+// Scan 扫描一次地址下的全部碎片
+func (t *Tracker) Scan(addr string) (*ScanResult, error) {
+	return t.scan(context.Background(), addr)
+}
+
+// ctx结束时停止扫描，事件通道阻塞时不再等待
+func (t *Tracker) scan(ctx context.Context, addr string) (*ScanResult, error) {
+	if t.client == nil {
+		return nil, ErrNoClient
+	}
+	if err := xbase.AddrValid(addr); err != nil {
+		return nil, err
+	}
+	if err := t.Policy.Valid(); err != nil {
+		return nil, err
+	}
+	if t.Policy.OnExpired == ActionConsume && t.Policy.Holder.GetAddress() != addr {
+		return nil, ErrNoHolder
+	}
+
+	limit := t.PageLimit
+	if limit <= 0 || limit > xbase.MaxLimit {
+		limit = xbase.MaxLimit
+	}
+	result := &ScanResult{Addr: addr}
+	for page := 1; ; page++ {
+		resp, _, err := t.client.ListShardsByAddr(&xbase.ListShardsByAddrParam{
+			Addr:  addr,
+			Page:  page,
+			Limit: limit,
+		})
+		if err != nil {
+			return result, err
+		}
+		for _, shard := range resp.List {
+			result.Scanned++
+			if err := t.check(ctx, addr, shard, result); err != nil {
+				return result, err
+			}
+		}
+		if len(resp.List) < limit || page*limit >= resp.TotalCnt {
+			break
+		}
+	}
+	return result, nil
+}
+
+// Run 按间隔循环扫描，直到ctx结束，扫描出错时继续下一轮，interval必须大于0
+func (t *Tracker) Run(ctx context.Context, interval time.Duration, addrs ...string) error {
+	if interval <= 0 {
+		return xbase.ErrParamInvalid
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, addr := range addrs {
+			if _, err := t.scan(ctx, addr); err != nil && ctx.Err() != nil {
+				return ctx.Err()
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// 只有ctx结束导致事件无法发出时返回错误
+func (t *Tracker) check(ctx context.Context, addr string, shard *xbase.QueryShardMeta, result *ScanResult) error {
+	if shard == nil || shard.ExpireTime <= 0 || !t.matchCate(shard) {
+		return nil
+	}
+	// 已核销、已冻结的碎片无需处理
+	if shard.Status.IsConsumed() || shard.Status.IsFrozen() {
+		return nil
+	}
+
+	window := t.Policy.Window
+	if window == 0 {
+		window = DefaultWindow
+	}
+	now := t.now()
+	expireAt := time.Unix(shard.ExpireTime, 0)
+	remaining := expireAt.Sub(now)
+	ev := Event{Addr: addr, Shard: shard, ExpireAt: expireAt, Remaining: remaining}
+
+	if remaining > window {
+		return nil
+	}
+	if remaining > 0 {
+		result.Expiring++
+		ev.Type = EventExpiring
+		return t.emitOnce(ctx, ev)
+	}
+
+	result.Expired++
+	ev.Type = EventExpired
+	if err := t.emitOnce(ctx, ev); err != nil {
+		return err
+	}
+
+	// 授予、转移中的碎片等待上链后再处理
+	if t.Policy.OnExpired == ActionNone || !shard.Status.IsTransferable() {
+		return nil
+	}
+	if err := t.act(shard); err != nil {
+		result.Failed++
+		ev.Type, ev.Err = EventActionFailed, err
+		return t.emit(ctx, ev)
+	}
+	if t.Policy.OnExpired == ActionConsume {
+		result.Consumed++
+		ev.Type = EventConsumed
+	} else {
+		result.Frozen++
+		ev.Type = EventFrozen
+	}
+	return t.emitOnce(ctx, ev)
+}
+
+func (t *Tracker) act(shard *xbase.QueryShardMeta) error {
+	switch t.Policy.OnExpired {
+	case ActionConsume:
+		holder := t.Policy.Holder
+		nonce := utils.GenNonce()
+		sign, err := holder.Sign([]byte(fmt.Sprintf("%d%d", shard.AssetId, nonce)))
+		if err != nil {
+			return xbase.ComErrAccountSignFailed
+		}
+		_, _, err = t.client.ConsumeShard(&xbase.ConsumeShardParam{
+			AssetId:  shard.AssetId,
+			ShardId:  shard.ShardId,
+			Nonce:    nonce,
+			UAddr:    holder.GetAddress(),
+			USign:    sign,
+			UPKey:    holder.GetPublicKey(),
+			CAccount: t.Policy.Creator,
+		})
+		return err
+	case ActionFreeze:
+		_, _, err := t.client.FreezeShard(&xbase.LockOrFreezeShardParam{
+			AssetId: shard.AssetId,
+			ShardId: shard.ShardId,
+			Account: t.Policy.Creator,
+		})
+		return err
+	}
+	return nil
+}
+
+func (t *Tracker) matchCate(shard *xbase.QueryShardMeta) bool {
+	if len(t.Policy.Cates) == 0 {
+		return true
+	}
+	if shard.AssetInfo == nil {
+		return false
+	}
+	for _, c := range t.Policy.Cates {
+		if int(c) == shard.AssetInfo.AssetCate {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Tracker) emitOnce(ctx context.Context, ev Event) error {
+	key := fmt.Sprintf("%d:%d", ev.Shard.AssetId, ev.Shard.ShardId)
+	t.mu.Lock()
+	if last, ok := t.notified[key]; ok && last >= ev.Type {
+		t.mu.Unlock()
+		return nil
+	}
+	t.notified[key] = ev.Type
+	t.mu.Unlock()
+	return t.emit(ctx, ev)
+}
+
+// 事件通道无人读取时阻塞，直到ctx结束
+func (t *Tracker) emit(ctx context.Context, ev Event) error {
+	t.mu.Lock()
+	handler, events := t.handler, t.events
+	t.mu.Unlock()
+	if handler != nil {
+		handler(ev)
+	}
+	if events == nil {
+		return nil
+	}
+	select {
+	case events <- ev:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
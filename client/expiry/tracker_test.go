@@ -0,0 +1,186 @@
+package expiry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	xbase "github.com/xuperchain/xasset-sdk-go/client/base"
+)
+
+type testClient struct {
+	shards   []*xbase.QueryShardMeta
+	pages    []int
+	consumed []*xbase.ConsumeShardParam
+	frozen   []*xbase.LockOrFreezeShardParam
+	failFrz  bool
+}
+
+func (t *testClient) ListShardsByAddr(param *xbase.ListShardsByAddrParam) (*xbase.ListShardsByAddrResp, *xbase.RequestRes, error) {
+	t.pages = append(t.pages, param.Page)
+	start := (param.Page - 1) * param.Limit
+	end := start + param.Limit
+	if start > len(t.shards) {
+		start = len(t.shards)
+	}
+	if end > len(t.shards) {
+		end = len(t.shards)
+	}
+	return &xbase.ListShardsByAddrResp{List: t.shards[start:end], TotalCnt: len(t.shards)}, nil, nil
+}
+
+func (t *testClient) ConsumeShard(param *xbase.ConsumeShardParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	t.consumed = append(t.consumed, param)
+	return &xbase.BaseResp{}, nil, nil
+}
+
+func (t *testClient) FreezeShard(param *xbase.LockOrFreezeShardParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	if t.failFrz {
+		return nil, nil, errors.New("freeze failed")
+	}
+	t.frozen = append(t.frozen, param)
+	return &xbase.BaseResp{}, nil, nil
+}
+
+var testNow = time.Unix(1700000000, 0)
+
+func testShard(shardId int64, expireIn time.Duration, cate xbase.AssetType) *xbase.QueryShardMeta {
+	var expire int64
+	if expireIn != 0 {
+		expire = testNow.Add(expireIn).Unix()
+	}
+	return &xbase.QueryShardMeta{
+		AssetId:    1,
+		ShardId:    shardId,
+		Status:     xbase.ShardStatusOnChain,
+		ExpireTime: expire,
+		AssetInfo:  &xbase.ShardAssetInfo{AssetCate: int(cate)},
+	}
+}
+
+func TestScanConsume(t *testing.T) {
+	holder := xbase.TestAccount
+	c := &testClient{shards: []*xbase.QueryShardMeta{
+		testShard(1, 0, xbase.AssetCateECoupon),
+		testShard(2, time.Hour, xbase.AssetCateECoupon),
+		testShard(3, 48*time.Hour, xbase.AssetCateECoupon),
+		testShard(4, -time.Hour, xbase.AssetCateECoupon),
+		testShard(5, -time.Hour, xbase.AssetCateArt),
+	}}
+	tr := NewTracker(c, Policy{OnExpired: ActionConsume, Holder: holder, Cates: []xbase.AssetType{xbase.AssetCateECoupon}})
+	tr.PageLimit = 2
+	tr.now = func() time.Time { return testNow }
+
+	var events []Event
+	tr.OnEvent(func(ev Event) { events = append(events, ev) })
+
+	res, err := tr.Scan(holder.Address)
+	if err != nil {
+		t.Fatalf("scan failed.err:%v", err)
+	}
+	if len(c.pages) != 3 || res.Scanned != 5 {
+		t.Fatalf("paging error.pages:%v scanned:%d", c.pages, res.Scanned)
+	}
+	if res.Expiring != 1 || res.Expired != 1 || res.Consumed != 1 {
+		t.Fatalf("scan result error.%+v", res)
+	}
+	if len(events) != 3 || events[0].Type != EventExpiring || events[1].Type != EventExpired || events[2].Type != EventConsumed {
+		t.Fatalf("events error.%+v", events)
+	}
+	if len(c.consumed) != 1 || c.consumed[0].ShardId != 4 || c.consumed[0].UAddr != holder.Address {
+		t.Fatalf("consume param error.%+v", c.consumed)
+	}
+	p := c.consumed[0]
+	msg := []byte(fmt.Sprintf("%d%d", p.AssetId, p.Nonce))
+	if ok, _ := auth.XassetVerifyECDSA(holder.PublicKey, p.USign, msg); !ok {
+		t.Fatal("consume sign invalid")
+	}
+
+	// 再次扫描不重复通知
+	c.shards[3].Status = xbase.ShardStatusConsumed
+	events = nil
+	if _, err := tr.Scan(holder.Address); err != nil || len(events) != 0 {
+		t.Fatalf("duplicate events.%+v err:%v", events, err)
+	}
+
+	// 核销需要持有者账户与地址一致
+	if _, err := tr.Scan(xbase.TestTransAccount.Address); err != ErrNoHolder {
+		t.Fatalf("want ErrNoHolder, got %v", err)
+	}
+}
+
+func TestScanFreezeChannel(t *testing.T) {
+	c := &testClient{failFrz: true, shards: []*xbase.QueryShardMeta{
+		testShard(1, -time.Minute, xbase.AssetCateTicket),
+	}}
+	tr := NewTracker(c, Policy{OnExpired: ActionFreeze, Creator: xbase.TestAccount})
+	tr.now = func() time.Time { return testNow }
+	ch := tr.Events(10)
+
+	res, err := tr.Scan(xbase.TestTransAccount.Address)
+	if err != nil || res.Failed != 1 {
+		t.Fatalf("scan result error.%+v err:%v", res, err)
+	}
+	if ev := <-ch; ev.Type != EventExpired {
+		t.Fatalf("event error.%+v", ev)
+	}
+	if ev := <-ch; ev.Type != EventActionFailed || ev.Err == nil {
+		t.Fatalf("event error.%+v", ev)
+	}
+
+	// 失败后下一轮重试
+	c.failFrz = false
+	res, _ = tr.Scan(xbase.TestTransAccount.Address)
+	if res.Frozen != 1 || len(c.frozen) != 1 {
+		t.Fatalf("retry freeze error.%+v", res)
+	}
+	if ev := <-ch; ev.Type != EventFrozen {
+		t.Fatalf("event error.%+v", ev)
+	}
+
+	if err := (&Policy{OnExpired: ActionFreeze}).Valid(); err != ErrNoCreator {
+		t.Fatalf("want ErrNoCreator, got %v", err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	c := &testClient{}
+	tr := NewTracker(c, Policy{})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := tr.Run(ctx, 10*time.Millisecond, xbase.TestAccount.Address); err != context.DeadlineExceeded {
+		t.Fatalf("run should stop with ctx.err:%v", err)
+	}
+	if len(c.pages) < 2 {
+		t.Fatalf("run should scan repeatedly.%d", len(c.pages))
+	}
+	if err := tr.Run(context.Background(), 0, xbase.TestAccount.Address); err != xbase.ErrParamInvalid {
+		t.Fatalf("want ErrParamInvalid, got %v", err)
+	}
+}
+
+func TestRunBlockedChannel(t *testing.T) {
+	c := &testClient{shards: []*xbase.QueryShardMeta{
+		testShard(1, time.Hour, xbase.AssetCateECoupon),
+		testShard(2, time.Hour, xbase.AssetCateECoupon),
+	}}
+	tr := NewTracker(c, Policy{})
+	tr.now = func() time.Time { return testNow }
+	// 通道无人读取，ctx结束后Run应退出
+	tr.Events(0)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- tr.Run(ctx, time.Hour, xbase.TestAccount.Address) }()
+	select {
+	case err := <-done:
+		if err != context.DeadlineExceeded {
+			t.Fatalf("run should stop with ctx.err:%v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("run blocked on events channel")
+	}
+}
@@ -0,0 +1,380 @@
+package thumb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	xbase "github.com/xuperchain/xasset-sdk-go/client/base"
+)
+
+// 输出图片格式
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+)
+
+const (
+	// 默认JPEG压缩质量
+	DefaultQuality = 85
+	// 原图宽高和像素数上限，解码前检查，避免高压缩比图片解码占用大量内存
+	MaxImageSide   = 16384
+	MaxImagePixels = 40000000
+)
+
+var (
+	ErrSizeInvalid = errors.New("thumbnail size invalid, width and height must be positive")
+	ErrFormat      = errors.New("unsupported output format")
+	ErrNoUploader  = errors.New("uploader unset")
+	ErrImageSize   = errors.New("image too large or empty")
+)
+
+// 缩略图规格，生成的图片等比缩放到Width*Height框内，不会放大原图
+type Size struct {
+	Name   string `json:"name"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// 默认缩略图规格
+var DefaultSizes = []Size{
+	{Name: "s", Width: 240, Height: 240},
+	{Name: "m", Width: 480, Height: 480},
+	{Name: "l", Width: 960, Height: 960},
+}
+
+// 上传接口，*xasset.AssetOper 实现了该接口
+type Uploader interface {
+	UploadFile(param *xbase.UploadFileParam) (*xbase.UploadFileResp, *xbase.RequestRes, error)
+}
+
+// 生成的图片
+type Variant struct {
+	Size   Size   `json:"size"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Format string `json:"format"`
+	Data   []byte `json:"-"`
+	Link   string `json:"link,omitempty"`
+}
+
+// 图片处理配置
+type Pipeline struct {
+	// 缩略图规格，为空时使用DefaultSizes
+	Sizes []Size
+	// 输出格式，为空时与原图一致（非PNG原图统一输出JPEG）
+	Format string
+	// JPEG压缩质量，1-100
+	Quality int
+	// 是否同时上传原图作为详情图
+	KeepOrigin bool
+
+	uploader Uploader
+}
+
+func NewPipeline(uploader Uploader, sizes []Size) *Pipeline {
+	return &Pipeline{
+		Sizes:      sizes,
+		Quality:    DefaultQuality,
+		KeepOrigin: true,
+		uploader:   uploader,
+	}
+}
+
+// 处理参数
+// FileName 上传文件名，各规格文件名为 名称_规格.扩展名
+// 注意：文件路径和二进制串为二选一
+type ProcessParam struct {
+	Account  auth.Signer
+	FileName string
+	FilePath string
+	DataByte []byte
+}
+
+func (t *ProcessParam) Valid() error {
+	if t == nil {
+		return xbase.ErrNilPointer
+	}
+	if err := xbase.AccountValid(t.Account); err != nil {
+		return err
+	}
+	if err := xbase.DescValid(t.FileName); err != nil {
+		return err
+	}
+	if t.FilePath == "" && t.DataByte == nil {
+		return xbase.ErrBytesInvalid
+	}
+	return nil
+}
+
+// 处理结果
+type ProcessResult struct {
+	// 缩略图，顺序与Sizes一致
+	Thumbs []*Variant `json:"thumbs"`
+	// 原图，KeepOrigin为false时为空
+	Origin *Variant `json:"origin,omitempty"`
+}
+
+// 缩略图链接，可直接用于CreateAssetInfo.Thumb
+func (t *ProcessResult) ThumbLinks() []string {
+	links := make([]string, 0, len(t.Thumbs))
+	for _, v := range t.Thumbs {
+		links = append(links, v.Link)
+	}
+	return links
+}
+
+// 详情图链接，可直接用于CreateAssetInfo.ImgDesc
+func (t *ProcessResult) ImgDescLinks() []string {
+	if t.Origin == nil {
+		return t.ThumbLinks()
+	}
+	return []string{t.Origin.Link}
+}
+
+// 填充资产信息中的Thumb和ImgDesc
+func (t *ProcessResult) Apply(info *xbase.CreateAssetInfo) {
+	if info == nil {
+		return
+	}
+	info.Thumb = t.ThumbLinks()
+	info.ImgDesc = t.ImgDescLinks()
+}
+
+// Process 生成各规格缩略图并上传
+func (t *Pipeline) Process(param *ProcessParam) (*ProcessResult, error) {
+	if err := param.Valid(); err != nil {
+		return nil, err
+	}
+	if t.uploader == nil {
+		return nil, ErrNoUploader
+	}
+
+	data := param.DataByte
+	if param.FilePath != "" {
+		var err error
+		data, err = ioutil.ReadFile(param.FilePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	origin, thumbs, err := t.generate(data)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := path.Ext(param.FileName)
+	base := strings.TrimSuffix(param.FileName, ext)
+	for _, v := range thumbs {
+		name := fmt.Sprintf("%s_%s%s", base, v.Size.Name, formatExt(v.Format))
+		if err := t.upload(param.Account, name, v); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &ProcessResult{Thumbs: thumbs}
+	if t.KeepOrigin {
+		if err := t.upload(param.Account, param.FileName, origin); err != nil {
+			return nil, err
+		}
+		result.Origin = origin
+	}
+	return result, nil
+}
+
+// Generate 仅生成各规格缩略图，不上传
+func (t *Pipeline) Generate(data []byte) ([]*Variant, error) {
+	_, thumbs, err := t.generate(data)
+	return thumbs, err
+}
+
+func (t *Pipeline) generate(data []byte) (*Variant, []*Variant, error) {
+	sizes := t.Sizes
+	if len(sizes) == 0 {
+		sizes = DefaultSizes
+	}
+	for _, s := range sizes {
+		if s.Width <= 0 || s.Height <= 0 {
+			return nil, nil, ErrSizeInvalid
+		}
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("decode image failed.err:%v", err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width > MaxImageSide || cfg.Height > MaxImageSide ||
+		int64(cfg.Width)*int64(cfg.Height) > MaxImagePixels {
+		return nil, nil, ErrImageSize
+	}
+	src, srcFormat, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("decode image failed.err:%v", err)
+	}
+	format := t.Format
+	if format == "" {
+		format = FormatJPEG
+		if srcFormat == FormatPNG {
+			format = FormatPNG
+		}
+	}
+	if format != FormatJPEG && format != FormatPNG {
+		return nil, nil, ErrFormat
+	}
+
+	b := src.Bounds()
+	origin := &Variant{
+		Size:   Size{Name: "origin", Width: b.Dx(), Height: b.Dy()},
+		Width:  b.Dx(),
+		Height: b.Dy(),
+		Format: srcFormat,
+		Data:   data,
+	}
+
+	rgba := toRGBA(src)
+	thumbs := make([]*Variant, 0, len(sizes))
+	for _, s := range sizes {
+		w, h := fitSize(b.Dx(), b.Dy(), s.Width, s.Height)
+		dst := Resize(rgba, w, h)
+		out, err := encode(dst, format, t.Quality)
+		if err != nil {
+			return nil, nil, err
+		}
+		thumbs = append(thumbs, &Variant{
+			Size:   s,
+			Width:  w,
+			Height: h,
+			Format: format,
+			Data:   out,
+		})
+	}
+	return origin, thumbs, nil
+}
+
+func (t *Pipeline) upload(account auth.Signer, name string, v *Variant) error {
+	resp, _, err := t.uploader.UploadFile(&xbase.UploadFileParam{
+		Account:  account,
+		FileName: name,
+		DataByte: v.Data,
+		Property: fmt.Sprintf("%d_%d", v.Width, v.Height),
+	})
+	if err != nil {
+		return err
+	}
+	v.Link = resp.Link
+	return nil
+}
+
+// Resize 使用区域平均算法将图片缩放到w*h
+func Resize(src *image.RGBA, w, h int) *image.RGBA {
+	sb := src.Bounds()
+	sw, sh := sb.Dx(), sb.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	if sw == 0 || sh == 0 || w == 0 || h == 0 {
+		return dst
+	}
+
+	for dy := 0; dy < h; dy++ {
+		y0, y1 := span(dy, h, sh)
+		for dx := 0; dx < w; dx++ {
+			x0, x1 := span(dx, w, sw)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				off := src.PixOffset(sb.Min.X+x0, sb.Min.Y+sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint64(src.Pix[off])
+					g += uint64(src.Pix[off+1])
+					b += uint64(src.Pix[off+2])
+					a += uint64(src.Pix[off+3])
+					n++
+					off += 4
+				}
+			}
+
+			i := dst.PixOffset(dx, dy)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+// 目标像素i覆盖的原图像素区间[start, end)
+func span(i, dstLen, srcLen int) (int, int) {
+	start := i * srcLen / dstLen
+	end := (i + 1) * srcLen / dstLen
+	if end <= start {
+		end = start + 1
+	}
+	if end > srcLen {
+		end = srcLen
+	}
+	return start, end
+}
+
+// 等比缩放到框内，不放大
+func fitSize(w, h, maxW, maxH int) (int, int) {
+	if w <= maxW && h <= maxH {
+		return w, h
+	}
+	if w*maxH > h*maxW {
+		nh := h * maxW / w
+		if nh < 1 {
+			nh = 1
+		}
+		return maxW, nh
+	}
+	nw := w * maxH / h
+	if nw < 1 {
+		nw = 1
+	}
+	return nw, maxH
+}
+
+func toRGBA(src image.Image) *image.RGBA {
+	if rgba, ok := src.(*image.RGBA); ok {
+		return rgba
+	}
+	b := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+	return rgba
+}
+
+func encode(img image.Image, format string, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case FormatPNG:
+		err = png.Encode(&buf, img)
+	default:
+		if quality < 1 || quality > 100 {
+			quality = DefaultQuality
+		}
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encode image failed.err:%v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func formatExt(format string) string {
+	if format == FormatPNG {
+		return ".png"
+	}
+	return ".jpg"
+}
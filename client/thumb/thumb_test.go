@@ -0,0 +1,134 @@
+package thumb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	xbase "github.com/xuperchain/xasset-sdk-go/client/base"
+)
+
+type testUploader struct {
+	params []*xbase.UploadFileParam
+}
+
+func (t *testUploader) UploadFile(param *xbase.UploadFileParam) (*xbase.UploadFileResp, *xbase.RequestRes, error) {
+	t.params = append(t.params, param)
+	link := fmt.Sprintf("bos_v1://bucket/path/%s/%s", param.FileName, param.Property)
+	return &xbase.UploadFileResp{Link: link}, nil, nil
+}
+
+func genTestPng(t *testing.T, w, h int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode png failed.err:%v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestGenerate(t *testing.T) {
+	data := genTestPng(t, 400, 200)
+	p := NewPipeline(nil, []Size{
+		{Name: "s", Width: 100, Height: 100},
+		{Name: "xl", Width: 1000, Height: 1000},
+	})
+
+	vs, err := p.Generate(data)
+	if err != nil {
+		t.Fatalf("generate failed.err:%v", err)
+	}
+	if len(vs) != 2 {
+		t.Fatalf("variant count error.%d", len(vs))
+	}
+	if vs[0].Width != 100 || vs[0].Height != 50 || vs[0].Format != FormatPNG {
+		t.Fatalf("variant s error.%+v", vs[0])
+	}
+	// 不放大原图
+	if vs[1].Width != 400 || vs[1].Height != 200 {
+		t.Fatalf("variant xl error.%+v", vs[1])
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(vs[0].Data))
+	if err != nil {
+		t.Fatalf("decode variant failed.err:%v", err)
+	}
+	if img.Bounds().Dx() != 100 || img.Bounds().Dy() != 50 {
+		t.Fatalf("variant bounds error.%v", img.Bounds())
+	}
+
+	p.Sizes = []Size{{Name: "bad", Width: 0, Height: 10}}
+	if _, err := p.Generate(data); err != ErrSizeInvalid {
+		t.Fatalf("invalid size should fail.err:%v", err)
+	}
+
+	// IHDR声明超大尺寸，解码前拒绝
+	p.Sizes = nil
+	huge := append([]byte(nil), data...)
+	binary.BigEndian.PutUint32(huge[16:20], MaxImageSide)
+	binary.BigEndian.PutUint32(huge[20:24], MaxImageSide)
+	binary.BigEndian.PutUint32(huge[29:33], crc32.ChecksumIEEE(huge[12:29]))
+	if _, err := p.Generate(huge); err != ErrImageSize {
+		t.Fatalf("want ErrImageSize, got %v", err)
+	}
+}
+
+func TestProcess(t *testing.T) {
+	up := &testUploader{}
+	p := NewPipeline(up, []Size{{Name: "s", Width: 60, Height: 60}})
+	p.Format = FormatJPEG
+
+	res, err := p.Process(&ProcessParam{
+		Account:  xbase.TestAccount,
+		FileName: "cover.png",
+		DataByte: genTestPng(t, 120, 90),
+	})
+	if err != nil {
+		t.Fatalf("process failed.err:%v", err)
+	}
+	if len(up.params) != 2 {
+		t.Fatalf("upload count error.%d", len(up.params))
+	}
+	if up.params[0].FileName != "cover_s.jpg" || up.params[0].Property != "60_45" {
+		t.Fatalf("thumb upload param error.%+v", up.params[0])
+	}
+	if up.params[1].FileName != "cover.png" || up.params[1].Property != "120_90" {
+		t.Fatalf("origin upload param error.%+v", up.params[1])
+	}
+
+	info := &xbase.CreateAssetInfo{}
+	res.Apply(info)
+	if len(info.Thumb) != 1 || info.Thumb[0] != "bos_v1://bucket/path/cover_s.jpg/60_45" {
+		t.Fatalf("thumb links error.%v", info.Thumb)
+	}
+	if len(info.ImgDesc) != 1 || info.ImgDesc[0] != "bos_v1://bucket/path/cover.png/120_90" {
+		t.Fatalf("img desc links error.%v", info.ImgDesc)
+	}
+
+	if _, err := p.Process(&ProcessParam{Account: xbase.TestAccount, FileName: "x.png"}); err == nil {
+		t.Fatal("process without data should fail")
+	}
+}
+
+func TestResize(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for i := range src.Pix {
+		src.Pix[i] = 200
+	}
+	dst := Resize(src, 2, 2)
+	for _, v := range dst.Pix {
+		if v != 200 {
+			t.Fatalf("resize average error.%v", dst.Pix)
+		}
+	}
+}
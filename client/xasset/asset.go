@@ -7,12 +7,15 @@ import (
 	"strconv"
 
 	auth2 "github.com/baidubce/bce-sdk-go/auth"
+	"github.com/baidubce/bce-sdk-go/bce"
 	"github.com/baidubce/bce-sdk-go/services/bos"
 
 	"github.com/xuperchain/xasset-sdk-go/auth"
 	xbase "github.com/xuperchain/xasset-sdk-go/client/base"
 	"github.com/xuperchain/xasset-sdk-go/common/config"
 	"github.com/xuperchain/xasset-sdk-go/common/logs"
+	"github.com/xuperchain/xasset-sdk-go/common/redact"
+	"github.com/xuperchain/xasset-sdk-go/common/transfer"
 	"github.com/xuperchain/xasset-sdk-go/utils"
 )
 
@@ -30,6 +33,13 @@ func NewAssetOperCli(cfg *config.XassetCliConfig, logger logs.LogDriver) (*Asset
 	return obj, nil
 }
 
+// WithOptions 返回应用了调用选项的客户端副本，所有方法都会使用这些选项
+//
+//	handle.WithOptions(xbase.WithTimeout(time.Second), xbase.WithIdempotencyKey(key)).Grant(param)
+func (t *AssetOper) WithOptions(opts ...xbase.CallOption) *AssetOper {
+	return &AssetOper{XassetBaseClient: *t.XassetBaseClient.WithOptions(opts...)}
+}
+
 // genGetStokenBody Grant uses the general parameter as follows,
 //
 //	   {
@@ -41,15 +51,15 @@ func NewAssetOperCli(cfg *config.XassetCliConfig, logger logs.LogDriver) (*Asset
 func (t *AssetOper) genGetStokenBody(param *xbase.GetStokenParam) (string, error) {
 	nonce := utils.GenNonce()
 	signMsg := fmt.Sprintf("%d", nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+	sign, err := param.Account.Sign([]byte(signMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
 
 	v := url.Values{}
-	v.Set("addr", param.Account.Address)
+	v.Set("addr", param.Account.GetAddress())
 	v.Set("sign", sign)
-	v.Set("pkey", param.Account.PublicKey)
+	v.Set("pkey", param.Account.GetPublicKey())
 	v.Set("nonce", fmt.Sprintf("%d", nonce))
 	body := v.Encode()
 	return body, nil
@@ -62,35 +72,28 @@ func (t *AssetOper) GetStoken(param *xbase.GetStokenParam) (*xbase.GetStokenResp
 
 	body, err := t.genGetStokenBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for getting stoken, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for getting stoken", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.FileApiGetStoken, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.GetStokenResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [accessInfo: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		resp.AccessInfo, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -101,45 +104,52 @@ func (t *AssetOper) UploadFile(param *xbase.UploadFileParam) (*xbase.UploadFileR
 
 	resp, res, err := t.GetStoken(&xbase.GetStokenParam{Account: param.Account})
 	if err != nil {
-		t.Logger.Warn("get stoken failed.[url:%s] [request_id:%s] [err_no:%d] [trace_id:%s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, nil, err
 	}
 
 	bosClient, err := bos.NewClient(resp.AccessInfo.AK, resp.AccessInfo.SK, resp.AccessInfo.EndPoint)
 	if err != nil {
-		t.Logger.Warn("create bos client failed.err:%v", err)
+		t.Logger.Log(logs.LevelWarn, "create bos client failed", logs.F(logs.FieldErr, err))
 		return nil, nil, err
 	}
 	stsCredential, err := auth2.NewSessionBceCredentials(resp.AccessInfo.AK, resp.AccessInfo.SK, resp.AccessInfo.SessionToken)
 	if err != nil {
-		t.Logger.Warn("create sts credential object failed.err:%v", err)
+		t.Logger.Log(logs.LevelWarn, "create sts credential object failed", logs.F(logs.FieldErr, err))
 		return nil, nil, err
 	}
 	bosClient.Config.Credentials = stsCredential
 
 	key := fmt.Sprintf("/%s%s", resp.AccessInfo.ObjectPath, param.FileName)
 
+	var body *bce.Body
 	if param.FilePath != "" {
-		_, err = bosClient.PutObjectFromFile(resp.AccessInfo.Bucket, key, param.FilePath, nil)
+		body, err = bce.NewBodyFromFile(param.FilePath)
 		if err != nil {
-			t.Logger.Warn("upload file through local file failed.err:%v", err)
+			t.Logger.Log(logs.LevelWarn, "read local file failed", logs.F(logs.FieldErr, err))
 			return nil, nil, err
 		}
 	} else if param.DataByte != nil {
-		_, err = bosClient.PutObjectFromBytes(resp.AccessInfo.Bucket, key, param.DataByte, nil)
+		body, err = bce.NewBodyFromBytes(param.DataByte)
 		if err != nil {
-			t.Logger.Warn("upload file through bytes failed.err:%v", err)
+			t.Logger.Log(logs.LevelWarn, "read bytes failed", logs.F(logs.FieldErr, err))
 			return nil, nil, err
 		}
 	} else {
-		t.Logger.Warn("unsupported upload file method")
+		t.Logger.Log(logs.LevelWarn, "unsupported upload file method")
 		return nil, nil, fmt.Errorf("wrong upload file method")
 	}
+	if param.Progress != nil || param.Limiter != nil {
+		body.SetStream(transfer.NewReader(body.Stream(), body.Size(), param.Limiter, param.Progress))
+	}
+
+	_, err = bosClient.PutObject(resp.AccessInfo.Bucket, key, body, nil)
+	if err != nil {
+		t.Logger.Log(logs.LevelWarn, "upload file failed", logs.F("file_name", param.FileName), logs.F(logs.FieldErr, err))
+		return nil, nil, err
+	}
 
 	link := fmt.Sprintf("bos_v1://%s%s/%s", resp.AccessInfo.Bucket, key, param.Property)
-	t.Logger.Trace("upload file succ.[link:%s] [url:%s] [request_id:%s] [trace_id:%s]",
-		link, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
+	t.Logger.Log(logs.LevelTrace, "upload file succ", logs.F("link", link), logs.F(logs.FieldRequestId, resp.RequestId))
 
 	return &xbase.UploadFileResp{
 		Link:       link,
@@ -162,31 +172,29 @@ func (t *AssetOper) UploadFile(param *xbase.UploadFileParam) (*xbase.UploadFileR
 //			FileHash  string `json:"file_hash,omitempty"`
 //	}
 func (t *AssetOper) genCreateAssetBody(appid int64, param *xbase.CreateAssetParam) (string, error) {
+	req, err := t.prepareCreateAsset(appid, param)
+	if err != nil {
+		return "", err
+	}
+	return signBody(req, param.Account)
+}
+
+func (t *AssetOper) prepareCreateAsset(appid int64, param *xbase.CreateAssetParam) (*xbase.UnsignedReq, error) {
 	nonce := utils.GenNonce()
 	assetId := param.AssetId
 	// generate assetId if not specified
 	if assetId == 0 {
 		assetId = utils.GenAssetId(appid)
 	}
-	signMsg := fmt.Sprintf("%d%d", assetId, nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
-	if err != nil {
-		return "", xbase.ComErrAccountSignFailed
-	}
 	assetInfo, err := json.Marshal(param.AssetInfo)
 	if err != nil {
-		return "", xbase.ComErrJsonMarFailed
+		return nil, xbase.ComErrJsonMarFailed
 	}
 
 	v := url.Values{}
-	v.Set("asset_id", fmt.Sprintf("%d", assetId))
 	v.Set("price", fmt.Sprintf("%d", param.Price))
 	v.Set("amount", fmt.Sprintf("%d", param.Amount))
 	v.Set("asset_info", string(assetInfo))
-	v.Set("addr", param.Account.Address)
-	v.Set("sign", sign)
-	v.Set("pkey", param.Account.PublicKey)
-	v.Set("nonce", fmt.Sprintf("%d", nonce))
 	v.Set("view_type", fmt.Sprintf("%d", param.ViewType))
 	v.Set("param", param.AssetParam)
 	if err := xbase.IdValid(param.UserId); err == nil {
@@ -195,8 +203,16 @@ func (t *AssetOper) genCreateAssetBody(appid int64, param *xbase.CreateAssetPara
 	if param.FileHash != "" {
 		v.Set("file_hash", param.FileHash)
 	}
-	body := v.Encode()
-	return body, nil
+	return xbase.NewUnsignedReq(xbase.OfflineOpCreate, assetId, nonce, param.Account.GetAddress(), v)
+}
+
+// 在线签名生成请求体，签名原文为asset_id+nonce
+func signBody(req *xbase.UnsignedReq, signer auth.Signer) (string, error) {
+	sign, err := signer.Sign(req.SignMsg())
+	if err != nil {
+		return "", xbase.ComErrAccountSignFailed
+	}
+	return req.SignedBody(sign, signer.GetPublicKey()), nil
 }
 
 func (t *AssetOper) CreateAsset(param *xbase.CreateAssetParam) (*xbase.CreateAssetResp, *xbase.RequestRes, error) {
@@ -204,37 +220,34 @@ func (t *AssetOper) CreateAsset(param *xbase.CreateAssetParam) (*xbase.CreateAss
 		return nil, nil, err
 	}
 
-	body, err := t.genCreateAssetBody(t.GetConfig().Credentials.AppId, param)
+	body, err := t.genCreateAssetBody(t.Cfg.Credentials.AppId, param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for creating, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for creating", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
+	return t.postCreateAsset(body)
+}
+
+func (t *AssetOper) postCreateAsset(body string) (*xbase.CreateAssetResp, *xbase.RequestRes, error) {
 	res, err := t.Post(xbase.AssetApiCreate, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.AssetApiCreate, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.CreateAssetResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		resp.AssetId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -253,16 +266,16 @@ func (t *AssetOper) CreateAsset(param *xbase.CreateAssetParam) (*xbase.CreateAss
 func (t *AssetOper) genAlterAssetBody(param *xbase.AlterAssetParam) (string, error) {
 	nonce := utils.GenNonce()
 	signMsg := fmt.Sprintf("%d%d", param.AssetId, nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+	sign, err := param.Account.Sign([]byte(signMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
 
 	v := url.Values{}
 	v.Set("asset_id", fmt.Sprintf("%d", param.AssetId))
-	v.Set("addr", param.Account.Address)
+	v.Set("addr", param.Account.GetAddress())
 	v.Set("sign", sign)
-	v.Set("pkey", param.Account.PublicKey)
+	v.Set("pkey", param.Account.GetPublicKey())
 	v.Set("nonce", fmt.Sprintf("%d", nonce))
 
 	if err := xbase.PriceInvalid(param.Price); err == nil {
@@ -297,35 +310,28 @@ func (t *AssetOper) AlterAsset(param *xbase.AlterAssetParam) (*xbase.BaseResp, *
 
 	body, err := t.genAlterAssetBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for altering, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for altering", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.AssetApiAlter, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -340,22 +346,17 @@ func (t *AssetOper) AlterAsset(param *xbase.AlterAssetParam) (*xbase.BaseResp, *
 //		    IsEvidence int    `json:"is_evidence,omitempty"`
 //	}
 func (t *AssetOper) genPublishAssetBody(param *xbase.PublishAssetParam) (string, error) {
-	nonce := utils.GenNonce()
-	signMsg := fmt.Sprintf("%d%d", param.AssetId, nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+	req, err := t.preparePublishAsset(param)
 	if err != nil {
-		return "", xbase.ComErrAccountSignFailed
+		return "", err
 	}
+	return signBody(req, param.Account)
+}
 
+func (t *AssetOper) preparePublishAsset(param *xbase.PublishAssetParam) (*xbase.UnsignedReq, error) {
 	v := url.Values{}
-	v.Set("asset_id", fmt.Sprintf("%d", param.AssetId))
-	v.Set("addr", param.Account.Address)
-	v.Set("sign", sign)
-	v.Set("pkey", param.Account.PublicKey)
-	v.Set("nonce", fmt.Sprintf("%d", nonce))
 	v.Set("is_evidence", fmt.Sprintf("%d", param.IsEvidence))
-	body := v.Encode()
-	return body, nil
+	return xbase.NewUnsignedReq(xbase.OfflineOpPublish, param.AssetId, utils.GenNonce(), param.Account.GetAddress(), v)
 }
 
 func (t *AssetOper) PublishAsset(param *xbase.PublishAssetParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
@@ -364,35 +365,32 @@ func (t *AssetOper) PublishAsset(param *xbase.PublishAssetParam) (*xbase.BaseRes
 	}
 	body, err := t.genPublishAssetBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for publishing, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for publishing", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
+	return t.postPublishAsset(body)
+}
+
+func (t *AssetOper) postPublishAsset(body string) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	res, err := t.Post(xbase.AssetApiPublish, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -416,30 +414,22 @@ func (t *AssetOper) QueryAsset(param *xbase.QueryAssetParam) (*xbase.QueryAssetR
 
 	res, err := t.Post(xbase.AssetApiQueryAsset, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.QueryAssetResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [meta: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		resp.Meta, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -469,30 +459,22 @@ func (t *AssetOper) ListAssetsByAddr(param *xbase.ListAssetsByAddrParam) (*xbase
 
 	res, err := t.Post(xbase.AssetApiListAssetByAddr, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.ListAssetsByAddrResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [total_cnt: %d] [url: %s] [request_id: %s] [trace_id: %s]", resp.TotalCnt,
-		res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -502,7 +484,7 @@ func (t *AssetOper) ListAssetsByAddr(param *xbase.ListAssetsByAddrParam) (*xbase
 //		    Addr   string `json:"addr"`
 //	   	Limit  int    `json:"limit"`
 //	  	Cursor string `json:"cursor"`
-//	   	OpTyps string `json:"op_types"`
+//	   	OpTyps []DiffOp `json:"op_types"`
 //		  }
 func (t *AssetOper) genListDiffByAddrBody(param *xbase.ListDiffByAddrParam) (string, error) {
 	v := url.Values{}
@@ -513,8 +495,8 @@ func (t *AssetOper) genListDiffByAddrBody(param *xbase.ListDiffByAddrParam) (str
 	if param.Cursor != "" {
 		v.Set("cursor", param.Cursor)
 	}
-	if param.OpTyps != "" {
-		v.Set("op_types", param.OpTyps)
+	if len(param.OpTyps) > 0 {
+		v.Set("op_types", xbase.EncodeDiffOps(param.OpTyps))
 	}
 	body := v.Encode()
 	return body, nil
@@ -528,30 +510,22 @@ func (t *AssetOper) ListDiffByAddr(param *xbase.ListDiffByAddrParam) (*xbase.Lis
 
 	res, err := t.Post(xbase.AssetApiListDiffByAddr, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post req resp not 200.[http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.ListDiffByAddrResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed.err:%v [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			err, res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [url: %s] [request_id: %s] [trace_id: %s]",
-		res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -569,13 +543,14 @@ func (t *AssetOper) ListDiffByAddr(param *xbase.ListDiffByAddrParam) (*xbase.Lis
 //	 	   Price 	int64  `json:"price",omitempty`
 //		  }
 func (t *AssetOper) genGrantAssetBody(appid int64, param *xbase.GrantAssetParam) (string, error) {
-	nonce := utils.GenNonce()
-	signMsg := fmt.Sprintf("%d%d", param.AssetId, nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+	req, err := t.prepareGrantAsset(param)
 	if err != nil {
-		return "", xbase.ComErrAccountSignFailed
+		return "", err
 	}
+	return signBody(req, param.Account)
+}
 
+func (t *AssetOper) prepareGrantAsset(param *xbase.GrantAssetParam) (*xbase.UnsignedReq, error) {
 	// 未指定shard_id，生成一个唯一值
 	shardId := param.ShardId
 	if shardId < 1 {
@@ -583,19 +558,14 @@ func (t *AssetOper) genGrantAssetBody(appid int64, param *xbase.GrantAssetParam)
 	}
 
 	v := url.Values{}
-	v.Set("asset_id", fmt.Sprintf("%d", param.AssetId))
 	v.Set("shard_id", fmt.Sprintf("%d", shardId))
 	v.Set("price", fmt.Sprintf("%d", param.Price))
-	v.Set("addr", param.Addr)
-	v.Set("sign", sign)
-	v.Set("pkey", param.Account.PublicKey)
-	v.Set("nonce", fmt.Sprintf("%d", nonce))
 	v.Set("to_addr", param.ToAddr)
 	v.Set("param", param.ShardParam)
 	if err := xbase.IdValid(param.ToUserId); err == nil {
 		v.Set("to_userid", fmt.Sprintf("%d", param.ToUserId))
 	}
-	return v.Encode(), nil
+	return xbase.NewUnsignedReq(xbase.OfflineOpGrant, param.AssetId, utils.GenNonce(), param.Addr, v)
 }
 
 // GrantAsset grants a random shard to the specific address for the very first time after the maker publishes its asset.
@@ -604,37 +574,34 @@ func (t *AssetOper) GrantAsset(param *xbase.GrantAssetParam) (*xbase.GrantAssetR
 		return nil, nil, err
 	}
 
-	body, err := t.genGrantAssetBody(t.GetConfig().Credentials.AppId, param)
+	body, err := t.genGrantAssetBody(t.Cfg.Credentials.AppId, param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for granting, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for granting", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
+	return t.postGrantAsset(body)
+}
+
+func (t *AssetOper) postGrantAsset(body string) (*xbase.GrantAssetResp, *xbase.RequestRes, error) {
 	res, err := t.Post(xbase.AssetApiGrant, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, asset_id: %d, err: %v", xbase.AssetApiGrant, param.AssetId, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [asset_id: %d] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, param.AssetId, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.GrantAssetResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [asset_id: %d] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, param.AssetId, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [asset_id: %d] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, param.AssetId, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %v] [shard_id: %v] [from: %s] [to: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		resp.AssetId, resp.ShardId, param.Addr, param.ToAddr, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -652,26 +619,22 @@ func (t *AssetOper) GrantAsset(param *xbase.GrantAssetParam) (*xbase.GrantAssetR
 //			   ToUserId int64  `json:"to_userid,omitempty"`
 //		  }
 func (t *AssetOper) genTransferAssetBody(param *xbase.TransferAssetParam) (string, error) {
-	nonce := utils.GenNonce()
-	signMsg := fmt.Sprintf("%d%d", param.AssetId, nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+	req, err := t.prepareTransferAsset(param)
 	if err != nil {
-		return "", xbase.ComErrAccountSignFailed
+		return "", err
 	}
+	return signBody(req, param.Account)
+}
 
+func (t *AssetOper) prepareTransferAsset(param *xbase.TransferAssetParam) (*xbase.UnsignedReq, error) {
 	v := url.Values{}
-	v.Set("asset_id", fmt.Sprintf("%d", param.AssetId))
 	v.Set("shard_id", fmt.Sprintf("%d", param.ShardId))
 	v.Set("price", fmt.Sprintf("%d", param.Price))
-	v.Set("addr", param.Addr)
-	v.Set("sign", sign)
-	v.Set("pkey", param.Account.PublicKey)
-	v.Set("nonce", fmt.Sprintf("%d", nonce))
 	v.Set("to_addr", param.ToAddr)
 	if err := xbase.IdValid(param.ToUserId); err == nil {
 		v.Set("to_userid", fmt.Sprintf("%d", param.ToUserId))
 	}
-	return v.Encode(), nil
+	return xbase.NewUnsignedReq(xbase.OfflineOpTransfer, param.AssetId, utils.GenNonce(), param.Addr, v)
 }
 
 // GrantAsset transfer th specific shard from address A to address B.
@@ -682,35 +645,32 @@ func (t *AssetOper) TransferAsset(param *xbase.TransferAssetParam) (*xbase.BaseR
 
 	body, err := t.genTransferAssetBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for transferring, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for transferring", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
+	return t.postTransferAsset(body)
+}
+
+func (t *AssetOper) postTransferAsset(body string) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	res, err := t.Post(xbase.AssetApiTransfer, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.AssetApiTransfer, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [shard_id: %d] [from: %s] [to: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, param.ShardId, param.Addr, param.ToAddr, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -736,30 +696,22 @@ func (t *AssetOper) QueryShard(param *xbase.QueryShardParam) (*xbase.QueryShardR
 
 	res, err := t.Post(xbase.AssetApiQueryShard, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.QueryShardResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [meta:%+v] [url:%s] [request_id:%s] [trace_id:%s]",
-		resp.Meta, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -779,11 +731,7 @@ func (t *AssetOper) genListShardsByAddrBody(param *xbase.ListShardsByAddrParam)
 		v.Set("asset_id", fmt.Sprintf("%d", param.AssetId))
 	}
 	if param.Status != nil {
-		intStatus, ok := param.Status.(int)
-		if !ok {
-			return "", xbase.ErrStatusInvalid
-		}
-		v.Set("status", fmt.Sprintf("%d", intStatus))
+		v.Set("status", fmt.Sprintf("%d", *param.Status))
 	}
 
 	body := v.Encode()
@@ -801,30 +749,22 @@ func (t *AssetOper) ListShardsByAddr(param *xbase.ListShardsByAddrParam) (*xbase
 
 	res, err := t.Post(xbase.AssetApiListShardsByAddr, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.ListShardsByAddrResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [total_cnt: %d] [url: %s] [request_id: %s] [trace_id: %s]", resp.TotalCnt,
-		res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -852,30 +792,22 @@ func (t *AssetOper) ListShardsByAsset(param *xbase.ListShardsByAssetParam) (*xba
 
 	res, err := t.Post(xbase.AssetListShardsByAsset, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.ListShardsByAssetResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [cursor: %s] [has_more: %d] [url: %s] [request_id: %s] [trace_id: %s]", resp.Cursor, resp.HasMore,
-		res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -897,24 +829,19 @@ func (t *AssetOper) ListAssetHistory(param *xbase.ListAssetHisParam) (*xbase.Lis
 
 	res, err := t.Post(xbase.ListAssetHistory, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.ListAssetHistory, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.ListAssetHistoryResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, nil, xbase.ComErrUnmarshalBodyFailed
 	}
-	t.Logger.Trace("operate succ. [asset_id: %d] [url: %s] [request_id: %s] [trace_id: %s] [resp: %+v]",
-		param.AssetId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header), resp)
 	return &resp, res, nil
 }
 
@@ -938,30 +865,22 @@ func (t *AssetOper) GetEvidenceInfo(param *xbase.GetEvidenceInfoParam) (*xbase.G
 
 	res, err := t.Post(xbase.AssetApiGetEvidenceInfo, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.GetEvidenceInfoResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [create_addr: %s] [tx_id: %s] [asset_info: %v] [ctime: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		resp.CreateAddr, resp.TxId, resp.AssetInfo, resp.Ctime, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -969,21 +888,21 @@ func (t *AssetOper) GetEvidenceInfo(param *xbase.GetEvidenceInfoParam) (*xbase.G
 //
 //	   {
 //			   AssetId  int64  			`json:"asset_id"`
-//			   Account  *auth.Account	`json:"account"`
+//			   Account  auth.Signer	`json:"account"`
 //		  }
 func (t *AssetOper) genFreezeAssetBody(param *xbase.FreezeAssetParam) (string, error) {
 	nonce := utils.GenNonce()
 	signMsg := fmt.Sprintf("%d%d", param.AssetId, nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+	sign, err := param.Account.Sign([]byte(signMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
 
 	v := url.Values{}
 	v.Set("asset_id", fmt.Sprintf("%d", param.AssetId))
-	v.Set("addr", param.Account.Address)
+	v.Set("addr", param.Account.GetAddress())
 	v.Set("sign", sign)
-	v.Set("pkey", param.Account.PublicKey)
+	v.Set("pkey", param.Account.GetPublicKey())
 	v.Set("nonce", fmt.Sprintf("%d", nonce))
 	return v.Encode(), nil
 }
@@ -996,35 +915,28 @@ func (t *AssetOper) FreezeAsset(param *xbase.FreezeAssetParam) (*xbase.BaseResp,
 
 	body, err := t.genFreezeAssetBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for freeze, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for freeze", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.AssetApiFreeze, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.AssetApiFreeze, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1058,35 +970,28 @@ func (t *AssetOper) ConsumeShard(param *xbase.ConsumeShardParam) (*xbase.BaseRes
 
 	body, err := t.genConsumeShardBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for consume, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for consume", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.AssetApiConsume, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.AssetApiConsume, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [shard_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, param.ShardId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1112,35 +1017,28 @@ func (t *AssetOper) SelectBoxAst(param *xbase.SelBoxAstParam) (*xbase.SelBoxAstR
 
 	body, err := t.genSelBoxAstBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for select box asset, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for select box asset", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.AssetApiSelectBoxAst, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.SelBoxAstResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [real_asset_id: %d] [token: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		resp.RealAstId, resp.Token, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1148,22 +1046,22 @@ func (t *AssetOper) SelectBoxAst(param *xbase.SelBoxAstParam) (*xbase.SelBoxAstR
 //
 //	   {
 //				Token        string
-//				UAccount 	 *auth.Account
-//				CAccount 	 *auth.Account
+//				UAccount 	 auth.Signer
+//				CAccount 	 auth.Signer
 //				AssetId      int64
 //				UserId       int64
 //		  }
 func (t *AssetOper) genGrantBoxBody(param *xbase.GrantBoxParam) (string, error) {
 	consumeNonce := utils.GenNonce()
 	consumeSignMsg := fmt.Sprintf("%d%d", param.BoxAssetId, consumeNonce)
-	uSign, err := auth.XassetSignECDSA(param.UAccount.PrivateKey, []byte(consumeSignMsg))
+	uSign, err := param.UAccount.Sign([]byte(consumeSignMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
 
 	grantNonce := utils.GenNonce()
 	grantSignMsg := fmt.Sprintf("%d%d", param.RealAssetId, grantNonce)
-	cSign, err := auth.XassetSignECDSA(param.CAccount.PrivateKey, []byte(grantSignMsg))
+	cSign, err := param.CAccount.Sign([]byte(grantSignMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
@@ -1174,12 +1072,12 @@ func (t *AssetOper) genGrantBoxBody(param *xbase.GrantBoxParam) (string, error)
 	v.Set("consume_nonce", fmt.Sprintf("%d", consumeNonce))
 	v.Set("grant_nonce", fmt.Sprintf("%d", grantNonce))
 	v.Set("token", param.Token)
-	v.Set("user_addr", param.UAccount.Address)
+	v.Set("user_addr", param.UAccount.GetAddress())
 	v.Set("user_sign", uSign)
-	v.Set("user_pkey", param.UAccount.PublicKey)
-	v.Set("create_addr", param.CAccount.Address)
+	v.Set("user_pkey", param.UAccount.GetPublicKey())
+	v.Set("create_addr", param.CAccount.GetAddress())
 	v.Set("create_sign", cSign)
-	v.Set("create_pkey", param.CAccount.PublicKey)
+	v.Set("create_pkey", param.CAccount.GetPublicKey())
 	v.Set("user_id", fmt.Sprintf("%d", param.UserId))
 
 	body := v.Encode()
@@ -1193,35 +1091,28 @@ func (t *AssetOper) GrantBox(param *xbase.GrantBoxParam) (*xbase.GrantBoxResp, *
 
 	body, err := t.genGrantBoxBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for grant box asset, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for grant box asset", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.AssetApiGrantBox, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.GrantBoxResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [shard_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		resp.AssetId, resp.ShardId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1248,35 +1139,28 @@ func (t *AssetOper) SelectMaterial(param *xbase.SelMaterialParam) (*xbase.SelMat
 
 	body, err := t.genSelMaterialBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for select material, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for select material", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.AssetApiSelectMaterial, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.SelMaterialResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [select_cnt: %d] [token: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		len(resp.List), resp.Token, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1301,34 +1185,28 @@ func (t *AssetOper) UpgradeAst(param *xbase.UpgradeAstParam) (*xbase.BaseResp, *
 
 	body, err := t.genUpgradeAstBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for upgrade asset, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for upgrade asset", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.AssetApiUpgradeAst, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [url: %s] [request_id: %s] [trace_id: %s]", res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1355,34 +1233,28 @@ func (t *AssetOper) UpgradeSds(param *xbase.UpgradeSdsParam) (*xbase.BaseResp, *
 
 	body, err := t.genUpgradeSdsBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for upgrade shard, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for upgrade shard", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.AssetApiUpgradeSds, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ.[url: %s] [request_id: %s] [trace_id: %s]", res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1397,7 +1269,7 @@ func (t *AssetOper) genComposeShardBody(consumeList []*xbase.AssetShardPair, par
 		//TODO need generate absolute uniq nonce
 		nonce := utils.GenNonce()
 		signMsg := fmt.Sprintf("%d%d", shard.AssetId, nonce)
-		sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+		sign, err := param.Account.Sign([]byte(signMsg))
 		if err != nil {
 			return "", xbase.ComErrAccountSignFailed
 		}
@@ -1417,7 +1289,7 @@ func (t *AssetOper) genComposeShardBody(consumeList []*xbase.AssetShardPair, par
 	//build grant sign
 	nonce := utils.GenNonce()
 	signMsg := fmt.Sprintf("%d%d", param.AssetId, nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+	sign, err := param.Account.Sign([]byte(signMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
@@ -1426,11 +1298,11 @@ func (t *AssetOper) genComposeShardBody(consumeList []*xbase.AssetShardPair, par
 	v.Set("asset_id", fmt.Sprintf("%d", param.AssetId))
 	v.Set("strg_no", fmt.Sprintf("%d", param.StrgNo))
 	v.Set("nonce", fmt.Sprintf("%d", nonce))
-	v.Set("addr", param.Account.Address)
-	v.Set("pkey", param.Account.PublicKey)
+	v.Set("addr", param.Account.GetAddress())
+	v.Set("pkey", param.Account.GetPublicKey())
 	v.Set("sign", sign)
-	v.Set("uaddr", param.UAccount.Address)
-	v.Set("upkey", param.UAccount.PublicKey)
+	v.Set("uaddr", param.UAccount.GetAddress())
+	v.Set("upkey", param.UAccount.GetPublicKey())
 	v.Set("ast_list", string(jsAstList))
 	v.Set("token", param.Token)
 	body := v.Encode()
@@ -1444,35 +1316,28 @@ func (t *AssetOper) ComposeShard(consumeList []*xbase.AssetShardPair, param *xba
 
 	body, err := t.genComposeShardBody(consumeList, param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for compose shard, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for compose shard", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.AssetApiComposeShard, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.ComposeResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [shard_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		resp.AssetId, resp.ShardId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1491,7 +1356,7 @@ func (t *AssetOper) genLockShardBody(param *xbase.LockOrFreezeShardParam) (strin
 	nonce := utils.GenNonce()
 	assetId := param.AssetId
 	signMsg := fmt.Sprintf("%d%d", assetId, nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+	sign, err := param.Account.Sign([]byte(signMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
@@ -1499,9 +1364,9 @@ func (t *AssetOper) genLockShardBody(param *xbase.LockOrFreezeShardParam) (strin
 	v.Set("asset_id", fmt.Sprintf("%d", assetId))
 	v.Set("shard_id", fmt.Sprintf("%d", param.ShardId))
 	v.Set("op_type", fmt.Sprintf("%d", param.OpType))
-	v.Set("addr", param.Account.Address)
+	v.Set("addr", param.Account.GetAddress())
 	v.Set("sign", sign)
-	v.Set("pkey", param.Account.PublicKey)
+	v.Set("pkey", param.Account.GetPublicKey())
 	v.Set("nonce", fmt.Sprintf("%d", nonce))
 	body := v.Encode()
 	return body, nil
@@ -1514,35 +1379,28 @@ func (t *AssetOper) LockShard(param *xbase.LockOrFreezeShardParam) (*xbase.BaseR
 
 	body, err := t.genLockShardBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for locking shard, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for locking shard", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.AssetApiLockShard, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.AssetApiLockShard, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [shard_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, param.ShardId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1560,16 +1418,16 @@ func (t *AssetOper) genFreezeShardBody(param *xbase.LockOrFreezeShardParam) (str
 	nonce := utils.GenNonce()
 	assetId := param.AssetId
 	signMsg := fmt.Sprintf("%d%d", assetId, nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+	sign, err := param.Account.Sign([]byte(signMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
 	v := url.Values{}
 	v.Set("asset_id", fmt.Sprintf("%d", assetId))
 	v.Set("shard_id", fmt.Sprintf("%d", param.ShardId))
-	v.Set("addr", param.Account.Address)
+	v.Set("addr", param.Account.GetAddress())
 	v.Set("sign", sign)
-	v.Set("pkey", param.Account.PublicKey)
+	v.Set("pkey", param.Account.GetPublicKey())
 	v.Set("nonce", fmt.Sprintf("%d", nonce))
 	body := v.Encode()
 	return body, nil
@@ -1582,35 +1440,28 @@ func (t *AssetOper) FreezeShard(param *xbase.LockOrFreezeShardParam) (*xbase.Bas
 
 	body, err := t.genFreezeShardBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for freezing shard, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for freezing shard", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.AssetApiFreezeShard, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.AssetApiFreezeShard, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [shard_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, param.ShardId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1621,35 +1472,28 @@ func (t *AssetOper) UnFreezeShard(param *xbase.LockOrFreezeShardParam) (*xbase.B
 
 	body, err := t.genFreezeShardBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for unfreezing shard, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for unfreezing shard", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.AssetApiUnfreezeShard, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.AssetApiUnfreezeShard, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [shard_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, param.ShardId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1680,35 +1524,28 @@ func (t *AssetOper) SceneListShardByAddr(param *xbase.SceneListShardByAddrParam)
 
 	body, err := t.genSceneListShardByAddrBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for scene listshardbyaddr, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for scene listshardbyaddr", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.SceneListShardByAddr, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.SceneListShardByAddr, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.SceneListShardByAddrResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [addr: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.Addr, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1738,35 +1575,28 @@ func (t *AssetOper) SceneQueryShard(param *xbase.SceneQueryShardParam) (*xbase.S
 
 	body, err := t.genSceneQueryShardBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for scene queryshard, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for scene queryshard", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.SceneQueryShard, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.SceneQueryShard, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.SceneQueryShardResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [addr: %s] [asset_id: %d] [shard_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.Addr, param.AssetId, param.ShardId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1777,7 +1607,7 @@ func (t *AssetOper) SceneQueryShard(param *xbase.SceneQueryShardParam) (*xbase.S
 //			Token  string `json:"token"`
 //	   	Limit  int    `json:"limit"`
 //	  	Cursor string `json:"cursor"`
-//	   	OpTyps string `json:"op_types"`
+//	   	OpTyps []DiffOp `json:"op_types"`
 //		  }
 func (t *AssetOper) genSceneListDiffByAddrBody(param *xbase.SceneListDiffByAddrParam) (string, error) {
 	v := url.Values{}
@@ -1789,8 +1619,8 @@ func (t *AssetOper) genSceneListDiffByAddrBody(param *xbase.SceneListDiffByAddrP
 	if param.Cursor != "" {
 		v.Set("cursor", param.Cursor)
 	}
-	if param.OpTyps != "" {
-		v.Set("op_types", param.OpTyps)
+	if len(param.OpTyps) > 0 {
+		v.Set("op_types", xbase.EncodeDiffOps(param.OpTyps))
 	}
 	body := v.Encode()
 	return body, nil
@@ -1804,30 +1634,22 @@ func (t *AssetOper) SceneListDiffByAddr(param *xbase.SceneListDiffByAddrParam) (
 
 	res, err := t.Post(xbase.SceneListDiffByAddr, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post req resp not 200.[http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.ListDiffByAddrResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed.err:%v [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			err, res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [url: %s] [request_id: %s] [trace_id: %s]",
-		res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1855,30 +1677,22 @@ func (t *AssetOper) SceneHasAssetByAddr(param *xbase.SceneHasAssetByAddrParam) (
 
 	res, err := t.Post(xbase.SceneHasAstByAddr, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. url: %s, err: %v", xbase.SceneHasAstByAddr, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post req resp not 200.[http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.SceneHasAssetByAddrResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed.err:%v [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			err, res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [addr: %s] [token: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.Addr, param.Token, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1888,7 +1702,7 @@ func (t *AssetOper) SceneListAddr(uid string) (*xbase.SceneListAddrResp, *xbase.
 	}
 	signedUnionId, err := t.aesEncodeStr(uid)
 	if err != nil {
-		t.Logger.Warn("encode union id fail, union id: %s", uid)
+		t.Logger.Log(logs.LevelWarn, "encode union id fail", logs.F("union_id", redact.Partial(uid)), logs.F(logs.FieldErr, err))
 		return nil, nil, err
 	}
 	v := url.Values{}
@@ -1897,30 +1711,22 @@ func (t *AssetOper) SceneListAddr(uid string) (*xbase.SceneListAddrResp, *xbase.
 
 	res, err := t.Post(xbase.SceneListAddr, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. url: %s, err: %v", xbase.SceneListAddr, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post req resp not 200.[http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.SceneListAddrResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed.err:%v [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			err, res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [union_id: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		uid, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1931,12 +1737,12 @@ func (t *AssetOper) BdBoxRegister(param *xbase.BdBoxRegisterParam) (*xbase.BdBox
 	v := url.Values{}
 	signedOpenId, err := t.aesEncodeStr(param.OpenId)
 	if err != nil {
-		t.Logger.Warn("encode open id fail, open id: %s", param.OpenId)
+		t.Logger.Log(logs.LevelWarn, "encode open id fail", logs.F("open_id", param.OpenId), logs.F(logs.FieldErr, err))
 		return nil, nil, err
 	}
 	signedAppKey, err := t.aesEncodeStr(param.AppKey)
 	if err != nil {
-		t.Logger.Warn("encode app key fail, app key: %s", param.AppKey)
+		t.Logger.Log(logs.LevelWarn, "encode app key fail", logs.F("app_key", param.AppKey), logs.F(logs.FieldErr, err))
 		return nil, nil, err
 	}
 	v.Set("open_id", signedOpenId)
@@ -1945,39 +1751,31 @@ func (t *AssetOper) BdBoxRegister(param *xbase.BdBoxRegisterParam) (*xbase.BdBox
 
 	res, err := t.Post(xbase.DidApiRegister, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. url: %s, err: %v", xbase.DidApiRegister, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post req resp not 200.[http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BdBoxRegisterResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed.err:%v [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			err, res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
 
 	decodeMnem, err := t.aesDecodeStr(resp.Mnemonic)
 	if err != nil {
-		t.Logger.Warn("get resp succ but cannot decode mnemonic. [url: %s] [request_id: %s] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "get resp succ but cannot decode mnemonic", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldRequestId, resp.RequestId), logs.F(logs.FieldErr, err))
 		return &resp, res, err
 	}
 	resp.Mnemonic = decodeMnem
-
-	t.Logger.Trace("operate succ. [open_id: %s] [app_key: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.OpenId, param.AppKey, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1988,17 +1786,17 @@ func (t *AssetOper) BdBoxBind(param *xbase.BdBoxBindParam) (*xbase.BaseResp, *xb
 	v := url.Values{}
 	signedOpenId, err := t.aesEncodeStr(param.OpenId)
 	if err != nil {
-		t.Logger.Warn("encode open id fail, open id: %s", param.OpenId)
+		t.Logger.Log(logs.LevelWarn, "encode open id fail", logs.F("open_id", param.OpenId), logs.F(logs.FieldErr, err))
 		return nil, nil, err
 	}
 	signedAppKey, err := t.aesEncodeStr(param.AppKey)
 	if err != nil {
-		t.Logger.Warn("encode app key fail, app key: %s", param.AppKey)
+		t.Logger.Log(logs.LevelWarn, "encode app key fail", logs.F("app_key", param.AppKey), logs.F(logs.FieldErr, err))
 		return nil, nil, err
 	}
-	signedMnem, err := t.aesEncodeStr(param.Mnemonic)
+	signedMnem, err := t.aesEncodeStr(auth.NormalizeMnemonic(param.Mnemonic))
 	if err != nil {
-		t.Logger.Warn("encode mnemonic fail, mnemonic: %s", param.Mnemonic)
+		t.Logger.Log(logs.LevelWarn, "encode mnemonic fail", logs.F(logs.FieldErr, err))
 		return nil, nil, err
 	}
 	v.Set("open_id", signedOpenId)
@@ -2008,30 +1806,22 @@ func (t *AssetOper) BdBoxBind(param *xbase.BdBoxBindParam) (*xbase.BaseResp, *xb
 
 	res, err := t.Post(xbase.DidApiBind, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. url: %s, err: %v", xbase.DidApiBind, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post req resp not 200.[http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed.err:%v [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			err, res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [open_id: %s] [app_key: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.OpenId, param.AppKey, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -2042,12 +1832,13 @@ func (t *AssetOper) BindByUnionId(param *xbase.BindByUnionIdParam) (*xbase.BaseR
 
 	signedUnionId, err := t.aesEncodeStr(param.UnionId)
 	if err != nil {
-		t.Logger.Warn("encode union id fail, union id: %s", param.UnionId)
+		t.Logger.Log(logs.LevelWarn, "encode union id fail", logs.F("union_id", redact.Partial(param.UnionId)),
+			logs.F(logs.FieldErr, err))
 		return nil, nil, err
 	}
-	signedMnem, err := t.aesEncodeStr(param.Mnemonic)
+	signedMnem, err := t.aesEncodeStr(auth.NormalizeMnemonic(param.Mnemonic))
 	if err != nil {
-		t.Logger.Warn("encode mnemonic fail, mnemonic: %s", param.Mnemonic)
+		t.Logger.Log(logs.LevelWarn, "encode mnemonic fail", logs.F(logs.FieldErr, err))
 		return nil, nil, err
 	}
 	v := url.Values{}
@@ -2057,30 +1848,22 @@ func (t *AssetOper) BindByUnionId(param *xbase.BindByUnionIdParam) (*xbase.BaseR
 
 	res, err := t.Post(xbase.DidApiBindByUid, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. url: %s, err: %v", xbase.DidApiBindByUid, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post req resp not 200.[http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed.err:%v [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			err, res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [union_id: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.UnionId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -2090,7 +1873,7 @@ func (t *AssetOper) GetAddrByUnionId(uid string) (*xbase.GetAddrByUnionIdResp, *
 	}
 	signedUnionId, err := t.aesEncodeStr(uid)
 	if err != nil {
-		t.Logger.Warn("encode union id fail, union id: %s", uid)
+		t.Logger.Log(logs.LevelWarn, "encode union id fail", logs.F("union_id", redact.Partial(uid)), logs.F(logs.FieldErr, err))
 		return nil, nil, err
 	}
 	v := url.Values{}
@@ -2099,30 +1882,22 @@ func (t *AssetOper) GetAddrByUnionId(uid string) (*xbase.GetAddrByUnionIdResp, *
 
 	res, err := t.Post(xbase.DidApiGetAddrByUid, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. url: %s, err: %v", xbase.DidApiGetAddrByUid, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post req resp not 200.[http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.GetAddrByUnionIdResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed.err:%v [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			err, res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [union_id: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		uid, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -2148,30 +1923,22 @@ func (t *AssetOper) VilgText2Img(param *xbase.VilgText2ImgParam) (*xbase.VilgTex
 
 	res, err := t.Post(xbase.VilgApiText2Img, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.VilgText2ImgResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [taskId:%+v] [url:%s] [request_id:%s] [trace_id:%s]",
-		resp.TaskId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -2188,30 +1955,22 @@ func (t *AssetOper) VilgText2ImgV2(param *xbase.VilgText2ImgV2Param) (*xbase.Vil
 
 	res, err := t.Post(xbase.VilgApiText2ImgV2, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.VilgText2ImgResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [taskId:%+v] [url:%s] [request_id:%s] [trace_id:%s]",
-		resp.TaskId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -2226,59 +1985,43 @@ func (t *AssetOper) VilgGetImg(taskId int64) (*xbase.VilgGetImgResp, *xbase.Requ
 
 	res, err := t.Post(xbase.VilgApiGetImg, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.VilgGetImgResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [status:%+v] [url:%s] [request_id:%s] [trace_id:%s]",
-		resp.Task.Status, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *AssetOper) VilgBalance() (*xbase.VilgBalanceResp, *xbase.RequestRes, error) {
 	res, err := t.Post(xbase.VilgApiBalance, "")
 	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.VilgBalanceResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [balance:%+v] [url:%s] [request_id:%s] [trace_id:%s]",
-		resp.Balance, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
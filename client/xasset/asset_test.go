@@ -115,7 +115,7 @@ func CreatetAnAsset(account *auth.Account) (int64, error) {
 	return resp.AssetId, nil
 }
 
-func checkAssetDone(assetId int64, status int) error {
+func checkAssetDone(assetId int64, status base.AssetStatus) error {
 	qResp, _, err := handle.QueryAsset(&base.QueryAssetParam{
 		AssetId: assetId,
 	})
@@ -123,12 +123,12 @@ func checkAssetDone(assetId int64, status int) error {
 		return err
 	}
 	if qResp.Meta.Status != status {
-		return fmt.Errorf("oops, asset status: %d, want: %d", qResp.Meta.Status, status)
+		return fmt.Errorf("oops, asset status: %s, want: %s", qResp.Meta.Status, status)
 	}
 	return nil
 }
 
-func checkShardDone(assetId int64, shardId int64, status int) error {
+func checkShardDone(assetId int64, shardId int64, status base.ShardStatus) error {
 	qResp, _, err := handle.QueryShard(&base.QueryShardParam{
 		AssetId: assetId,
 		ShardId: shardId,
@@ -137,7 +137,7 @@ func checkShardDone(assetId int64, shardId int64, status int) error {
 		return err
 	}
 	if qResp.Meta.Status != status {
-		return fmt.Errorf("oops, shard status: %d, want: %d", qResp.Meta.Status, status)
+		return fmt.Errorf("oops, shard status: %s, want: %s", qResp.Meta.Status, status)
 	}
 	return nil
 }
@@ -209,7 +209,7 @@ func TestXasset(t *testing.T) {
 
 	// check onChain status
 	checkPublishFunc := func(...interface{}) error {
-		return checkAssetDone(assetId, 4)
+		return checkAssetDone(assetId, base.AssetStatusPublished)
 	}
 	done := ChainReady(checkPublishFunc)
 	<-done
@@ -231,7 +231,7 @@ func TestXasset(t *testing.T) {
 
 	// check shard onChain status
 	checkShardOnChain := func(...interface{}) error {
-		return checkShardDone(assetId, shardId, 0)
+		return checkShardDone(assetId, shardId, base.ShardStatusOnChain)
 	}
 	grantDone := ChainReady(checkShardOnChain)
 	<-grantDone
@@ -275,7 +275,7 @@ func TestXasset(t *testing.T) {
 
 	// check shard consume status
 	checkShardConsume := func(...interface{}) error {
-		return checkShardDone(assetId, shardId, 6)
+		return checkShardDone(assetId, shardId, base.ShardStatusConsumed)
 	}
 	consumeDone := ChainReady(checkShardConsume)
 	<-consumeDone
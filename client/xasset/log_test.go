@@ -0,0 +1,44 @@
+package xasset
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/xuperchain/xasset-sdk-go/client/base"
+)
+
+// 每次调用只由日志中间件输出一条结构化日志
+func TestLogOncePerCall(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == base.AssetApiQueryAsset {
+			fmt.Fprint(w, `{"errno":0,"request_id":"r1","meta":{"asset_id":1}}`)
+			return
+		}
+		fmt.Fprint(w, `{"errno":2001,"request_id":"r2"}`)
+	}))
+	defer ts.Close()
+
+	cfg := base.TestGetXassetConfig()
+	cfg.Endpoint = ts.URL
+	logger := &captureLogger{}
+	handle, err := NewAssetOperCli(cfg, logger)
+	if err != nil {
+		t.Fatalf("new client failed.err:%v", err)
+	}
+
+	if _, _, err := handle.QueryAsset(&base.QueryAssetParam{AssetId: 1}); err != nil {
+		t.Fatalf("query asset failed.err:%v", err)
+	}
+	if _, _, err := handle.FreezeAsset(&base.FreezeAssetParam{AssetId: 1, Account: base.TestAccount}); err != base.ComErrServRespErrnoErr {
+		t.Fatalf("want errno error, got %v", err)
+	}
+	if len(logger.msgs) != 2 {
+		t.Fatalf("want 2 logs, got %d.%v", len(logger.msgs), logger.msgs)
+	}
+	if !strings.Contains(logger.msgs[1], "[request_id:r2]") || !strings.Contains(logger.msgs[1], "[errno:2001]") {
+		t.Fatalf("log fields missing.%s", logger.msgs[1])
+	}
+}
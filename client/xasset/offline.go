@@ -0,0 +1,87 @@
+package xasset
+
+import (
+	"strconv"
+
+	xbase "github.com/xuperchain/xasset-sdk-go/client/base"
+	"github.com/xuperchain/xasset-sdk-go/common/logs"
+)
+
+// 离线签名流程：在线机器Prepare生成待签名请求，离线机器UnsignedReq.Sign签名，
+// 在线机器Submit提交。Prepare时param.Account只需提供地址，可使用auth.AddrOnly。
+
+func (t *AssetOper) PrepareCreateAsset(param *xbase.CreateAssetParam) (*xbase.UnsignedReq, error) {
+	if err := param.Valid(); err != nil {
+		return nil, err
+	}
+	return t.prepareCreateAsset(t.Cfg.Credentials.AppId, param)
+}
+
+func (t *AssetOper) PreparePublishAsset(param *xbase.PublishAssetParam) (*xbase.UnsignedReq, error) {
+	if err := param.Valid(); err != nil {
+		return nil, err
+	}
+	return t.preparePublishAsset(param)
+}
+
+func (t *AssetOper) PrepareGrantAsset(param *xbase.GrantAssetParam) (*xbase.UnsignedReq, error) {
+	if err := param.Valid(); err != nil {
+		return nil, err
+	}
+	return t.prepareGrantAsset(param)
+}
+
+func (t *AssetOper) PrepareTransferAsset(param *xbase.TransferAssetParam) (*xbase.UnsignedReq, error) {
+	if err := param.Valid(); err != nil {
+		return nil, err
+	}
+	return t.prepareTransferAsset(param)
+}
+
+func (t *AssetOper) SubmitCreateAsset(req *xbase.SignedReq) (*xbase.CreateAssetResp, *xbase.RequestRes, error) {
+	if err := t.checkSigned(req, xbase.OfflineOpCreate); err != nil {
+		return nil, nil, err
+	}
+	return t.postCreateAsset(req.Body())
+}
+
+func (t *AssetOper) SubmitPublishAsset(req *xbase.SignedReq) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	if err := t.checkSigned(req, xbase.OfflineOpPublish); err != nil {
+		return nil, nil, err
+	}
+	return t.postPublishAsset(req.Body())
+}
+
+func (t *AssetOper) SubmitGrantAsset(req *xbase.SignedReq) (*xbase.GrantAssetResp, *xbase.RequestRes, error) {
+	if err := t.checkSigned(req, xbase.OfflineOpGrant); err != nil {
+		return nil, nil, err
+	}
+	return t.postGrantAsset(req.Body())
+}
+
+func (t *AssetOper) SubmitTransferAsset(req *xbase.SignedReq) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	if err := t.checkSigned(req, xbase.OfflineOpTransfer); err != nil {
+		return nil, nil, err
+	}
+	// 提交前校验签名内容中的shard_id，与在线TransferAsset的参数校验一致
+	// shard_id已包含在签名请求体中，无需再单独传递
+	shardId, err := strconv.ParseInt(req.Fields["shard_id"], 10, 64)
+	if err != nil {
+		return nil, nil, xbase.ErrShardInvalid
+	}
+	if err := xbase.ShardIdValid(shardId); err != nil {
+		return nil, nil, err
+	}
+	return t.postTransferAsset(req.Body())
+}
+
+func (t *AssetOper) checkSigned(req *xbase.SignedReq, op xbase.OfflineOp) error {
+	if err := req.Valid(); err != nil {
+		t.Logger.Log(logs.LevelWarn, "offline request invalid", logs.F("op", op), logs.F(logs.FieldErr, err))
+		return err
+	}
+	if req.Op != op {
+		return xbase.ErrOfflineOp
+	}
+	return nil
+}
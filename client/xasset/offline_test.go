@@ -0,0 +1,144 @@
+package xasset
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	xbase "github.com/xuperchain/xasset-sdk-go/client/base"
+)
+
+func newOfflineTestOper(t *testing.T, handler http.HandlerFunc) *AssetOper {
+	cfg := xbase.TestGetXassetConfig()
+	if handler != nil {
+		ts := httptest.NewServer(handler)
+		t.Cleanup(ts.Close)
+		cfg.Endpoint = ts.URL
+	}
+	oper, err := NewAssetOperCli(cfg, &xbase.TestLogger{})
+	if err != nil {
+		t.Fatalf("new asset oper failed.err:%v", err)
+	}
+	return oper
+}
+
+func TestOfflineGrant(t *testing.T) {
+	creator := xbase.TestAccount
+	var got url.Values
+	oper := newOfflineTestOper(t, func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		got, _ = url.ParseQuery(string(data))
+		w.Write([]byte(`{"errno":0,"request_id":"r1","asset_id":100,"shard_id":200}`))
+	})
+
+	// 在线机器只知道地址
+	unsigned, err := oper.PrepareGrantAsset(&xbase.GrantAssetParam{
+		AssetId: 100,
+		ShardId: 200,
+		Account: auth.AddrOnly(creator.Address),
+		Addr:    creator.Address,
+		ToAddr:  xbase.TestTransAccount.Address,
+	})
+	if err != nil {
+		t.Fatalf("prepare failed.err:%v", err)
+	}
+	data, _ := json.Marshal(unsigned)
+
+	// 离线机器反序列化后签名
+	var offline xbase.UnsignedReq
+	if err := json.Unmarshal(data, &offline); err != nil {
+		t.Fatalf("unmarshal unsigned failed.err:%v", err)
+	}
+	if _, err := offline.Sign(xbase.TestTransAccount); err != xbase.ErrOfflineSigner {
+		t.Fatalf("want ErrOfflineSigner, got %v", err)
+	}
+	signed, err := offline.Sign(creator)
+	if err != nil {
+		t.Fatalf("sign failed.err:%v", err)
+	}
+	data, _ = json.Marshal(signed)
+
+	var online xbase.SignedReq
+	json.Unmarshal(data, &online)
+	if _, _, err := oper.SubmitTransferAsset(&online); err != xbase.ErrOfflineOp {
+		t.Fatalf("want ErrOfflineOp, got %v", err)
+	}
+	resp, _, err := oper.SubmitGrantAsset(&online)
+	if err != nil || resp.ShardId != 200 {
+		t.Fatalf("submit failed.err:%v", err)
+	}
+	if got.Get("shard_id") != "200" || got.Get("to_addr") != xbase.TestTransAccount.Address ||
+		got.Get("addr") != creator.Address || got.Get("pkey") != creator.PublicKey {
+		t.Fatalf("submit body error.%v", got)
+	}
+	if ok, _ := auth.XassetVerifyECDSA(creator.PublicKey, got.Get("sign"), []byte(got.Get("asset_id")+got.Get("nonce"))); !ok {
+		t.Fatal("submitted sign should verify")
+	}
+
+	// 签名后篡改接收地址
+	tampered := online
+	tampered.Fields = map[string]string{}
+	for k, v := range online.Fields {
+		tampered.Fields[k] = v
+	}
+	tampered.Fields["to_addr"] = "attacker"
+	if _, _, err := oper.SubmitGrantAsset(&tampered); err != xbase.ErrOfflineSeal {
+		t.Fatalf("want ErrOfflineSeal, got %v", err)
+	}
+	tampered = online
+	tampered.Nonce++
+	if _, _, err := oper.SubmitGrantAsset(&tampered); err != xbase.ErrOfflineSign {
+		t.Fatalf("want ErrOfflineSign, got %v", err)
+	}
+}
+
+func TestOfflineMatchesOnline(t *testing.T) {
+	oper := newOfflineTestOper(t, nil)
+	param := &xbase.PublishAssetParam{AssetId: 100, Account: xbase.TestAccount, IsEvidence: 1}
+
+	body, err := oper.genPublishAssetBody(param)
+	if err != nil {
+		t.Fatalf("gen body failed.err:%v", err)
+	}
+	online, _ := url.ParseQuery(body)
+
+	unsigned, err := oper.PreparePublishAsset(param)
+	if err != nil {
+		t.Fatalf("prepare failed.err:%v", err)
+	}
+	signed, err := unsigned.Sign(xbase.TestAccount)
+	if err != nil {
+		t.Fatalf("sign failed.err:%v", err)
+	}
+	offline, _ := url.ParseQuery(signed.Body())
+
+	// 除nonce和签名外请求体一致
+	for _, v := range []url.Values{online, offline} {
+		v.Del("nonce")
+		v.Del("sign")
+	}
+	if online.Encode() != offline.Encode() {
+		t.Fatalf("body mismatch.\n%s\n%s", online.Encode(), offline.Encode())
+	}
+
+	if _, err := oper.PrepareCreateAsset(&xbase.CreateAssetParam{Account: auth.AddrOnly("")}); err == nil {
+		t.Fatal("invalid param should fail")
+	}
+	if _, err := xbase.NewUnsignedReq(xbase.OfflineOpCreate, 1, 1, "addr", url.Values{"sign": {"x"}}); err != xbase.ErrOfflineReserved {
+		t.Fatalf("want ErrOfflineReserved, got %v", err)
+	}
+
+	// 缺少shard_id的转移请求不能按0提交
+	unsigned, _ = xbase.NewUnsignedReq(xbase.OfflineOpTransfer, 100, 1, xbase.TestAccount.Address,
+		url.Values{"to_addr": {xbase.TestTransAccount.Address}})
+	if signed, err = unsigned.Sign(xbase.TestAccount); err != nil {
+		t.Fatalf("sign failed.err:%v", err)
+	}
+	if _, _, err := oper.SubmitTransferAsset(signed); err != xbase.ErrShardInvalid {
+		t.Fatalf("want ErrShardInvalid, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package xasset
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	"github.com/xuperchain/xasset-sdk-go/client/base"
+)
+
+func TestAssetOperConcurrentOptions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"errno":0,"request_id":"%s"}`, r.Header.Get(base.HeaderIdempotencyKey))
+	}))
+	defer ts.Close()
+
+	cfg := base.TestGetXassetConfig()
+	cfg.Endpoint = ts.URL
+	handle, err := NewAssetOperCli(cfg, nil)
+	if err != nil {
+		t.Fatalf("new client failed.err:%v", err)
+	}
+	acc, _ := auth.NewXchainEcdsaAccount(auth.MnemStrgthWeak, auth.MnemLangEN)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("k%d", i)
+			resp, _, err := handle.WithOptions(base.WithIdempotencyKey(key)).GetStoken(&base.GetStokenParam{Account: acc})
+			if err != nil || resp.RequestId != key {
+				t.Errorf("call %s got %v %v", key, resp, err)
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			handle.SetHeader("X-Batch", fmt.Sprint(i))
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,85 @@
+package xasset
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	"github.com/xuperchain/xasset-sdk-go/client/base"
+)
+
+// 记录所有日志内容
+type captureLogger struct {
+	msgs []string
+}
+
+func (t *captureLogger) Error(msg string, ctx ...interface{}) { t.add(msg, ctx...) }
+func (t *captureLogger) Warn(msg string, ctx ...interface{})  { t.add(msg, ctx...) }
+func (t *captureLogger) Info(msg string, ctx ...interface{})  { t.add(msg, ctx...) }
+func (t *captureLogger) Trace(msg string, ctx ...interface{}) { t.add(msg, ctx...) }
+func (t *captureLogger) Debug(msg string, ctx ...interface{}) { t.add(msg, ctx...) }
+
+func (t *captureLogger) add(msg string, ctx ...interface{}) {
+	t.msgs = append(t.msgs, fmt.Sprintf(msg, ctx...))
+}
+
+func TestLogRedaction(t *testing.T) {
+	acc, _ := auth.NewXchainEcdsaAccount(auth.MnemStrgthStrong, auth.MnemLangEN)
+	// aes加密要求16字节
+	sk := "sk0123456789abcd"
+	token := "tk0123456789abcdef"
+	unionId := "union0123456789abcdef"
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case base.FileApiGetStoken:
+			fmt.Fprintf(w, `{"errno":0,"request_id":"1","accessInfo":{"bucket":"b","endpoint":"e",`+
+				`"object_path":"p","access_key_id":"ak","secret_access_key":"%s","session_token":"%s"}}`, sk, token)
+		default:
+			// 服务端异常时回显敏感信息，日志会打印body
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, `{"private_key":%q,"mnemonic":%q} %s`, acc.PrivateKey, acc.Mnemonic, acc.Mnemonic)
+		}
+	}))
+	defer ts.Close()
+
+	cfg := base.TestGetXassetConfig()
+	cfg.Endpoint = ts.URL
+	cfg.SetCredentials(int64(base.TestAppId), base.TestAK, sk)
+	logger := &captureLogger{}
+	handle, err := NewAssetOperCli(cfg, logger)
+	if err != nil {
+		t.Fatalf("new client failed.err:%v", err)
+	}
+
+	handle.GetStoken(&base.GetStokenParam{Account: acc})
+	handle.BindByUnionId(&base.BindByUnionIdParam{UnionId: unionId, Mnemonic: acc.Mnemonic})
+	handle.CreateAsset(&base.CreateAssetParam{
+		Amount: 1,
+		AssetInfo: &base.CreateAssetInfo{
+			AssetCate: 1,
+			Title:     "title",
+			ShortDesc: "desc",
+			Thumb:     []string{"bos_v1://b/p/1.jpg"},
+			AssetUrl:  []string{"bos_v1://b/p/1.jpg"},
+		},
+		Account: acc,
+	})
+	handle.Logger.Warn("config: %s, account: %+v, mnemonic: %s", cfg, *acc, acc.Mnemonic)
+
+	if len(logger.msgs) == 0 {
+		t.Fatalf("nothing logged")
+	}
+	priv, _ := auth.GetEcdsaPriKeyByJsStr(acc.PrivateKey)
+	firstWord := strings.Fields(acc.Mnemonic)[0] + " " + strings.Fields(acc.Mnemonic)[1]
+	for _, msg := range logger.msgs {
+		for _, secret := range []string{priv.D.String(), acc.Mnemonic, firstWord, sk, token, unionId} {
+			if strings.Contains(msg, secret) {
+				t.Fatalf("secret leaked to log: %s", msg)
+			}
+		}
+	}
+}
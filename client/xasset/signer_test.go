@@ -0,0 +1,36 @@
+package xasset
+
+import (
+	"net/url"
+	"testing"
+
+	xbase "github.com/xuperchain/xasset-sdk-go/client/base"
+)
+
+// 固定输出的签名者，用于离线校验请求体
+type fixedSigner struct {
+	msgs [][]byte
+}
+
+func (t *fixedSigner) GetAddress() string   { return "fixed_addr" }
+func (t *fixedSigner) GetPublicKey() string { return "fixed_pkey" }
+func (t *fixedSigner) Sign(msg []byte) (string, error) {
+	t.msgs = append(t.msgs, msg)
+	return "fixed_sign", nil
+}
+
+func TestGenBodyWithSigner(t *testing.T) {
+	s := &fixedSigner{}
+	oper := &AssetOper{}
+	body, err := oper.genGetStokenBody(&xbase.GetStokenParam{Account: s})
+	if err != nil {
+		t.Fatalf("gen body failed.err:%v", err)
+	}
+	v, _ := url.ParseQuery(body)
+	if v.Get("addr") != "fixed_addr" || v.Get("pkey") != "fixed_pkey" || v.Get("sign") != "fixed_sign" {
+		t.Fatalf("body error.%s", body)
+	}
+	if len(s.msgs) != 1 || string(s.msgs[0]) != v.Get("nonce") {
+		t.Fatalf("sign msg error.%q", s.msgs)
+	}
+}
@@ -0,0 +1,39 @@
+package xstore
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/xuperchain/xasset-sdk-go/client/base"
+)
+
+func TestStoreOperConcurrentOptions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"errno":0,"request_id":"%s"}`, r.Header.Get(base.HeaderTraceId))
+	}))
+	defer ts.Close()
+
+	cfg := base.TestGetXassetConfig()
+	cfg.Endpoint = ts.URL
+	handle, err := NewXstoreOper(cfg, nil)
+	if err != nil {
+		t.Fatalf("new client failed.err:%v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := fmt.Sprintf("t%d", i)
+			resp, _, err := handle.WithOptions(base.WithTraceId(id)).QueryStore(&base.BaseStoreParam{StoreId: 1})
+			if err != nil || resp.RequestId != id {
+				t.Errorf("call %s got %v %v", id, resp, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -27,6 +27,13 @@ func NewXstoreOper(cfg *config.XassetCliConfig, logger logs.LogDriver) (*StoreOp
 	return obj, nil
 }
 
+// WithOptions 返回应用了调用选项的客户端副本，所有方法都会使用这些选项
+//
+//	handle.WithOptions(xbase.WithTimeout(time.Second), xbase.WithIdempotencyKey(key)).Grant(param)
+func (t *StoreOper) WithOptions(opts ...xbase.CallOption) *StoreOper {
+	return &StoreOper{XassetBaseClient: *t.XassetBaseClient.WithOptions(opts...)}
+}
+
 func (t *StoreOper) genCreateOrAlterStoreBody(param *xbase.CreateOrAlterStoreParam) (string, error) {
 	v := url.Values{}
 	v.Set("store_id", fmt.Sprintf("%d", param.StoreId))
@@ -48,35 +55,28 @@ func (t *StoreOper) CreateStore(param *xbase.CreateOrAlterStoreParam) (*xbase.Ba
 
 	body, err := t.genCreateOrAlterStoreBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for create store, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for create store", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.StoreApiCreate, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [store_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.StoreId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -87,35 +87,28 @@ func (t *StoreOper) AlterStore(param *xbase.CreateOrAlterStoreParam) (*xbase.Bas
 
 	body, err := t.genCreateOrAlterStoreBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for alter store, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for alter store", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.StoreApiAlter, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [store_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.StoreId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -133,65 +126,50 @@ func (t *StoreOper) QueryStore(param *xbase.BaseStoreParam) (*xbase.QueryStoreRe
 
 	body, err := t.genQueryStoreBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for query store, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for query store", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.StoreApiQuery, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.QueryStoreResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [store_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.StoreId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *StoreOper) ListStore() (*xbase.ListStoreResp, *xbase.RequestRes, error) {
 	res, err := t.Post(xbase.StoreApiList, "")
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.ListStoreResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [url: %s] [request_id: %s] [trace_id: %s]",
-		res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -221,35 +199,28 @@ func (t *StoreOper) CreateAct(param *xbase.CreateOrAlterActParam) (*xbase.BaseRe
 
 	body, err := t.genCreateOrAlterActBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for create act, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for create act", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.StoreApiCreateAct, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [store_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.StoreId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -260,35 +231,28 @@ func (t *StoreOper) AlterAct(param *xbase.CreateOrAlterActParam) (*xbase.BaseRes
 
 	body, err := t.genCreateOrAlterActBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for alter act, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for alter act", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.StoreApiAlterAct, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [store_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.StoreId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -307,35 +271,28 @@ func (t *StoreOper) RemoveAct(param *xbase.BaseActParam) (*xbase.BaseResp, *xbas
 
 	body, err := t.genBaseActBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for remove act, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for remove act", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.StoreApiRemoveAct, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [act_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.ActId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -346,35 +303,28 @@ func (t *StoreOper) QueryAct(param *xbase.BaseActParam) (*xbase.QueryActResp, *x
 
 	body, err := t.genBaseActBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for query act, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for query act", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.StoreApiQueryAct, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.QueryActResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [act_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.ActId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -394,35 +344,28 @@ func (t *StoreOper) ListAct(param *xbase.ListActParam) (*xbase.ListActResp, *xba
 
 	body, err := t.genListActBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for list act, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for list act", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.StoreApiListAct, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.ListActResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [store_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.StoreId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -433,35 +376,28 @@ func (t *StoreOper) PubAct(param *xbase.BaseActParam) (*xbase.BaseResp, *xbase.R
 
 	body, err := t.genBaseActBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for pub act, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for pub act", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.StoreApiPubAct, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [act_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.ActId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -492,35 +428,28 @@ func (t *StoreOper) BindAst(param *xbase.BindOrAlterAstParam) (*xbase.BaseResp,
 
 	body, err := t.genBindOrAlterAstBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for bind ast, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for bind ast", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.StoreApiBindAst, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -531,35 +460,28 @@ func (t *StoreOper) AlterAst(param *xbase.BindOrAlterAstParam) (*xbase.BaseResp,
 
 	body, err := t.genBindOrAlterAstBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for alter ast, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for alter ast", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.StoreApiAlterAst, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -578,35 +500,28 @@ func (t *StoreOper) CancelAst(param *xbase.BaseAstParam) (*xbase.BaseResp, *xbas
 
 	body, err := t.genCancelAstBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for cancel ast, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for cancel ast", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.StoreApiCancelAst, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -625,35 +540,28 @@ func (t *StoreOper) CancelAstByActId(param *xbase.BaseActParam) (*xbase.BaseResp
 
 	body, err := t.genCancelAstByActIdBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for cancel ast by act_id, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for cancel ast by act_id", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.StoreApiCancelAstByActId, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [act_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.ActId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -672,35 +580,28 @@ func (t *StoreOper) QueryActAst(param *xbase.BaseAstParam) (*xbase.QueryActAstRe
 
 	body, err := t.genQueryActAstBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for query act ast, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for query act ast", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.StoreApiQueryAst, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.QueryActAstResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -718,35 +619,28 @@ func (t *StoreOper) ListActAst(param *xbase.BaseActParam) (*xbase.ListActAstResp
 
 	body, err := t.genListActAstBody(param)
 	if err != nil {
-		t.Logger.Warn("fail to generate value for list act ast, err: %v, param: %+v", err, *param)
+		t.Logger.Log(logs.LevelWarn, "fail to generate value for list act ast", logs.F(logs.FieldErr, err),
+			logs.F("param", *param))
 		return nil, nil, err
 	}
 	res, err := t.Post(xbase.StoreApiListAst, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.ListActAstResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [act_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.ActId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -803,30 +697,22 @@ func (t *StoreOper) CreateOrder(param *xbase.HubCreateOrderParam, uid int64, aut
 
 	res, err := t.Post(xbase.HubCreateOrder, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.HubCreateOrder, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.HubCreateResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -854,30 +740,22 @@ func (t *StoreOper) ConfirmOrder(param *xbase.HubConfirmH5OrderParam, auth strin
 	body := v.Encode()
 	res, err := t.Post(xbase.HubConfirmOrder, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.HubConfirmOrder, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.HubCreateResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -891,30 +769,22 @@ func (t *StoreOper) QueryOrderDetail(param *xbase.HubOrderDetailParam) (*xbase.H
 	body := v.Encode()
 	res, err := t.Post(xbase.HubDetailOrder, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.HubDetailOrder, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.HubOrderDetailResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -936,30 +806,22 @@ func (t *StoreOper) EditOrder(param *xbase.HubEditOrderParam) (*xbase.BaseResp,
 
 	res, err := t.Post(xbase.HubEditOrder, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.HubEditOrder, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -980,30 +842,22 @@ func (t *StoreOper) QueryOrderList(param *xbase.HubListOrderParam) (*xbase.HubLi
 	body := v.Encode()
 	res, err := t.Post(xbase.HubListOrder, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.HubListOrder, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.HubListOrderResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1023,30 +877,22 @@ func (t *StoreOper) QueryOrderPage(param *xbase.HubOrderPageParam) (*xbase.HubOr
 	body := v.Encode()
 	res, err := t.Post(xbase.HubListOrderPage, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.HubListOrderPage, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.HubOrderPageResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1064,30 +910,22 @@ func (t *StoreOper) CountOrder(param *xbase.CountOrderParam) (*xbase.CountOrderR
 	body := v.Encode()
 	res, err := t.Post(xbase.CountOrder, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.CountOrder, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.CountOrderResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1108,30 +946,22 @@ func (t *StoreOper) SumOrderPrice(param *xbase.SumOrderPriceParam) (*xbase.SumOr
 	body := v.Encode()
 	res, err := t.Post(xbase.SumOrderPrice, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.SumOrderPrice, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.SumOrderPriceResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1155,30 +985,22 @@ func (t *StoreOper) CheckRefund(param *xbase.CheckRefundParam) (*xbase.CheckRefu
 	body := v.Encode()
 	res, err := t.Post(xbase.CheckRefund, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.SumOrderPrice, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.CheckRefundResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1195,30 +1017,22 @@ func (t *StoreOper) CreateRefund(param *xbase.CreateRefundParam) (*xbase.CreateR
 	body := v.Encode()
 	res, err := t.Post(xbase.CreateRefund, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.SumOrderPrice, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.CreateRefundResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1236,30 +1050,22 @@ func (t *StoreOper) CancelRefund(param *xbase.CancelRefundParam) (*xbase.BaseRes
 	body := v.Encode()
 	res, err := t.Post(xbase.CancelRefund, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.SumOrderPrice, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1276,30 +1082,22 @@ func (t *StoreOper) ConfirmRefund(param *xbase.ConfirmRefundParam) (*xbase.BaseR
 	body := v.Encode()
 	res, err := t.Post(xbase.ConfirmRefund, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.SumOrderPrice, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1316,30 +1114,22 @@ func (t *StoreOper) RefuseRefund(param *xbase.RefuseRefundParam) (*xbase.BaseRes
 	body := v.Encode()
 	res, err := t.Post(xbase.RefuseRefund, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.SumOrderPrice, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.BaseResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1354,30 +1144,22 @@ func (t *StoreOper) QueryRefund(param *xbase.QueryRefundParam) (*xbase.QueryRefu
 	body := v.Encode()
 	res, err := t.Post(xbase.QueryRefund, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.QueryRefund, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.QueryRefundResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1400,30 +1182,22 @@ func (t *StoreOper) QueryRefundPage(param *xbase.QueryRefundPageParam) (*xbase.Q
 	body := v.Encode()
 	res, err := t.Post(xbase.QueryRefundPage, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.QueryRefundPage, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.QueryRefundPageResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1441,29 +1215,21 @@ func (t *StoreOper) SumRefundPrice(param *xbase.SumRefundPriceParam) (*xbase.Sum
 	body := v.Encode()
 	res, err := t.Post(xbase.SumRefundPrice, body)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.SumRefundPrice, err)
 		return nil, nil, xbase.ComErrRequsetFailed
 	}
 	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
 		return nil, nil, xbase.ComErrRespCodeErr
 	}
 
 	var resp xbase.SumRefundPriceResp
 	err = json.Unmarshal([]byte(res.Body), &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
+		t.Logger.Log(logs.LevelWarn, "unmarshal body failed", logs.F(logs.FieldUrl, res.ReqUrl),
+			logs.F(logs.FieldErr, err))
 		return nil, res, xbase.ComErrUnmarshalBodyFailed
 	}
 	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
 		return nil, res, xbase.ComErrServRespErrnoErr
 	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
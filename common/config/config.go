@@ -44,6 +44,32 @@ func (t *XassetCliConfig) SetCredentials(appId int64, ak, sk string) {
 	}
 }
 
+// Clone 深拷贝配置，客户端保存副本，初始化后修改原配置不影响客户端
+// 同时补全签名参数默认值，避免签名时并发写入
+func (t *XassetCliConfig) Clone() *XassetCliConfig {
+	res := *t
+	if t.Credentials != nil {
+		cred := *t.Credentials
+		res.Credentials = &cred
+	}
+	if t.SignOption != nil {
+		opt := *t.SignOption
+		if opt.HeadersToSign == nil {
+			opt.HeadersToSign = auth.DEFAULT_HEADERS_TO_SIGN
+		}
+		headers := make(map[string]struct{}, len(opt.HeadersToSign))
+		for k := range opt.HeadersToSign {
+			headers[k] = struct{}{}
+		}
+		opt.HeadersToSign = headers
+		if opt.ExpireSeconds < 1 {
+			opt.ExpireSeconds = auth.DEFAULT_EXPIRE_SECONDS
+		}
+		res.SignOption = &opt
+	}
+	return &res
+}
+
 func (t *XassetCliConfig) String() string {
 	return fmt.Sprintf("[Endpoint:%s] [UserAgent:%s] [Credentials:%v] [SignOption:%v] "+
 		"[ConnectTimeoutMs:%dms] [ReadWriteTimeoutMs:%dms]", t.Endpoint, t.UserAgent,
@@ -0,0 +1,391 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+)
+
+// 环境变量
+const (
+	EnvConfig             = "XASSET_CONFIG"
+	EnvProfile            = "XASSET_PROFILE"
+	EnvEndpoint           = "XASSET_ENDPOINT"
+	EnvAppId              = "XASSET_APP_ID"
+	EnvAccessKeyId        = "XASSET_ACCESS_KEY_ID"
+	EnvSecretAccessKey    = "XASSET_SECRET_ACCESS_KEY"
+	EnvUserAgent          = "XASSET_USER_AGENT"
+	EnvConnectTimeoutMs   = "XASSET_CONNECT_TIMEOUT_MS"
+	EnvReadWriteTimeoutMs = "XASSET_READ_WRITE_TIMEOUT_MS"
+)
+
+// 配置文件中的key
+const (
+	keyProfile            = "profile"
+	keyProfiles           = "profiles"
+	keyEndpoint           = "endpoint"
+	keyAppId              = "app_id"
+	keyAccessKeyId        = "access_key_id"
+	keySecretAccessKey    = "secret_access_key"
+	keyUserAgent          = "user_agent"
+	keyConnectTimeoutMs   = "connect_timeout_ms"
+	keyReadWriteTimeoutMs = "read_write_timeout_ms"
+)
+
+var ErrConfigFormat = errors.New("config file format unsupported")
+
+// 一组可选配置项，为nil的项不覆盖低优先级的值
+type Settings struct {
+	Endpoint           *string
+	AppId              *int64
+	AccessKeyId        *string
+	SecretAccessKey    *string
+	UserAgent          *string
+	ConnectTimeoutMs   *int
+	ReadWriteTimeoutMs *int
+}
+
+// 加载参数
+type LoadOptions struct {
+	// 配置文件，yaml或json，为空时读取XASSET_CONFIG，都为空时不读文件
+	File string
+	// profile名称，为空时读取XASSET_PROFILE，再为空时使用文件中的profile
+	Profile string
+	// 最高优先级的配置，如命令行参数
+	Overrides Settings
+	// 读取环境变量，默认os.LookupEnv
+	LookupEnv func(key string) (string, bool)
+}
+
+// 加载或校验时的全部错误
+type Errors []error
+
+func (t Errors) Error() string {
+	msgs := make([]string, 0, len(t))
+	for _, err := range t {
+		msgs = append(msgs, err.Error())
+	}
+	return "config invalid: " + strings.Join(msgs, "; ")
+}
+
+func (t *Errors) add(format string, args ...interface{}) {
+	*t = append(*t, fmt.Errorf(format, args...))
+}
+
+func (t Errors) orNil() error {
+	if len(t) == 0 {
+		return nil
+	}
+	return t
+}
+
+// Load 按 默认值 < 文件顶层 < 文件profile < XASSET_*环境变量 < Overrides 的优先级生成配置
+// endpoint没有默认值，加载和校验的错误一次性以Errors返回
+func Load(opts LoadOptions) (*XassetCliConfig, error) {
+	lookup := opts.LookupEnv
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	var errs Errors
+
+	file := opts.File
+	if file == "" {
+		file, _ = lookup(EnvConfig)
+	}
+	profile := opts.Profile
+	if profile == "" {
+		profile, _ = lookup(EnvProfile)
+	}
+
+	layers := make([]*Settings, 0, 4)
+	if file != "" {
+		// 文件无法读取或解析时记录错误，继续处理环境变量和Overrides
+		top, profiles, fileProfile, err := loadFile(file, &errs)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			layers = append(layers, top)
+			if profile == "" {
+				profile = fileProfile
+			}
+			if profile != "" {
+				if p, ok := profiles[profile]; ok {
+					layers = append(layers, p)
+				} else {
+					errs.add("profile %q not found in %s", profile, file)
+				}
+			}
+		}
+	} else if profile != "" {
+		errs.add("profile %q requires a config file", profile)
+	}
+	layers = append(layers, envSettings(lookup, &errs), &opts.Overrides)
+
+	// 不使用EndpointDefault，endpoint必须显式配置
+	cfg := NewXassetCliConf()
+	cfg.Endpoint = ""
+	cfg.Credentials = &auth.Credentials{}
+	for _, s := range layers {
+		s.apply(cfg)
+	}
+	if err := cfg.Validate(); err != nil {
+		errs = append(errs, err.(Errors)...)
+	}
+	if err := errs.orNil(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate 检查配置，返回全部错误
+func (t *XassetCliConfig) Validate() error {
+	var errs Errors
+	if t.Endpoint == "" {
+		errs.add("%s is required", keyEndpoint)
+	} else if u, err := url.Parse(t.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs.add("%s %q must be an http or https url", keyEndpoint, t.Endpoint)
+	}
+	if t.Credentials == nil {
+		errs.add("credentials are required")
+	} else {
+		if t.Credentials.AppId <= 0 {
+			errs.add("%s must be positive", keyAppId)
+		}
+		if t.Credentials.AccessKeyId == "" {
+			errs.add("%s is required", keyAccessKeyId)
+		}
+		if t.Credentials.SecretAccessKey == "" {
+			errs.add("%s is required", keySecretAccessKey)
+		}
+	}
+	if t.SignOption == nil {
+		errs.add("sign option is required")
+	}
+	if t.ConnectTimeoutMs <= 0 {
+		errs.add("%s must be positive", keyConnectTimeoutMs)
+	}
+	if t.ReadWriteTimeoutMs <= 0 {
+		errs.add("%s must be positive", keyReadWriteTimeoutMs)
+	}
+	return errs.orNil()
+}
+
+func (t *Settings) apply(cfg *XassetCliConfig) {
+	if t.Endpoint != nil {
+		cfg.Endpoint = *t.Endpoint
+	}
+	if t.AppId != nil {
+		cfg.Credentials.AppId = *t.AppId
+	}
+	if t.AccessKeyId != nil {
+		cfg.Credentials.AccessKeyId = *t.AccessKeyId
+	}
+	if t.SecretAccessKey != nil {
+		cfg.Credentials.SecretAccessKey = *t.SecretAccessKey
+	}
+	if t.UserAgent != nil {
+		cfg.UserAgent = *t.UserAgent
+	}
+	if t.ConnectTimeoutMs != nil {
+		cfg.ConnectTimeoutMs = *t.ConnectTimeoutMs
+	}
+	if t.ReadWriteTimeoutMs != nil {
+		cfg.ReadWriteTimeoutMs = *t.ReadWriteTimeoutMs
+	}
+}
+
+// 读取配置文件，格式错误直接返回，字段错误记录到errs
+func loadFile(path string, errs *Errors) (*Settings, map[string]*Settings, string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	raw, err := decodeFile(path, data)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("%s: %v", path, err)
+	}
+
+	var profile string
+	profiles := make(map[string]*Settings)
+	top := make(map[string]interface{}, len(raw))
+	for _, k := range sortedKeys(raw) {
+		v := raw[k]
+		switch k {
+		case keyProfile:
+			if s, ok := v.(string); ok {
+				profile = s
+			} else {
+				errs.add("%s: %s must be a string", path, keyProfile)
+			}
+		case keyProfiles:
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				errs.add("%s: %s must be a mapping", path, keyProfiles)
+				continue
+			}
+			for _, name := range sortedKeys(m) {
+				pm, ok := m[name].(map[string]interface{})
+				if !ok {
+					errs.add("%s: profile %q must be a mapping", path, name)
+					continue
+				}
+				profiles[name] = parseSettings(pm, fmt.Sprintf("%s: profile %q", path, name), errs)
+			}
+		default:
+			top[k] = v
+		}
+	}
+	return parseSettings(top, path, errs), profiles, profile, nil
+}
+
+func decodeFile(path string, data []byte) (map[string]interface{}, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return decodeJson(data)
+	case ".yaml", ".yml":
+		return parseYaml(string(data))
+	case "":
+		if strings.HasPrefix(strings.TrimSpace(string(data)), "{") {
+			return decodeJson(data)
+		}
+		return parseYaml(string(data))
+	}
+	return nil, ErrConfigFormat
+}
+
+func decodeJson(data []byte) (map[string]interface{}, error) {
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// 按key解析配置项，未知key和类型错误记录到errs
+func parseSettings(m map[string]interface{}, where string, errs *Errors) *Settings {
+	s := &Settings{}
+	for _, k := range sortedKeys(m) {
+		v := m[k]
+		if v == nil {
+			continue
+		}
+		var err error
+		switch k {
+		case keyEndpoint:
+			s.Endpoint, err = toString(v)
+		case keyAccessKeyId:
+			s.AccessKeyId, err = toString(v)
+		case keySecretAccessKey:
+			s.SecretAccessKey, err = toString(v)
+		case keyUserAgent:
+			s.UserAgent, err = toString(v)
+		case keyAppId:
+			s.AppId, err = toInt64(v)
+		case keyConnectTimeoutMs:
+			s.ConnectTimeoutMs, err = toInt(v)
+		case keyReadWriteTimeoutMs:
+			s.ReadWriteTimeoutMs, err = toInt(v)
+		default:
+			errs.add("%s: unknown key %q", where, k)
+			continue
+		}
+		if err != nil {
+			errs.add("%s: %s %v", where, k, err)
+		}
+	}
+	return s
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// 读取XASSET_*环境变量，空值视为未设置
+func envSettings(lookup func(string) (string, bool), errs *Errors) *Settings {
+	s := &Settings{}
+	str := func(key string) *string {
+		if v, ok := lookup(key); ok && v != "" {
+			return &v
+		}
+		return nil
+	}
+	s.Endpoint = str(EnvEndpoint)
+	s.AccessKeyId = str(EnvAccessKeyId)
+	s.SecretAccessKey = str(EnvSecretAccessKey)
+	s.UserAgent = str(EnvUserAgent)
+
+	var err error
+	if v := str(EnvAppId); v != nil {
+		if s.AppId, err = toInt64(*v); err != nil {
+			errs.add("%s %v", EnvAppId, err)
+		}
+	}
+	if v := str(EnvConnectTimeoutMs); v != nil {
+		if s.ConnectTimeoutMs, err = toInt(*v); err != nil {
+			errs.add("%s %v", EnvConnectTimeoutMs, err)
+		}
+	}
+	if v := str(EnvReadWriteTimeoutMs); v != nil {
+		if s.ReadWriteTimeoutMs, err = toInt(*v); err != nil {
+			errs.add("%s %v", EnvReadWriteTimeoutMs, err)
+		}
+	}
+	return s
+}
+
+func toString(v interface{}) (*string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("must be a string")
+	}
+	return &s, nil
+}
+
+// 兼容json数字、yaml整数和数字字符串
+func toInt64(v interface{}) (*int64, error) {
+	var n int64
+	switch x := v.(type) {
+	case int64:
+		n = x
+	case float64:
+		if x != math.Trunc(x) || x > math.MaxInt64 || x < math.MinInt64 {
+			return nil, fmt.Errorf("must be an integer")
+		}
+		n = int64(x)
+	case string:
+		var err error
+		if n, err = strconv.ParseInt(strings.TrimSpace(x), 10, 64); err != nil {
+			return nil, fmt.Errorf("must be an integer")
+		}
+	default:
+		return nil, fmt.Errorf("must be an integer")
+	}
+	return &n, nil
+}
+
+func toInt(v interface{}) (*int, error) {
+	n, err := toInt64(v)
+	if err != nil {
+		return nil, err
+	}
+	if *n > math.MaxInt32 || *n < math.MinInt32 {
+		return nil, fmt.Errorf("out of range")
+	}
+	i := int(*n)
+	return &i, nil
+}
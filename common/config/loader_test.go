@@ -0,0 +1,188 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testYaml = `# xasset sdk config
+profile: dev
+app_id: 100
+access_key_id: ak-default
+secret_access_key: 'sk#default'   # 引号内的#不是注释
+connect_timeout_ms: 500
+
+profiles:
+  dev:
+    endpoint: http://127.0.0.1:8360
+  prod:
+    endpoint: "https://xasset.example.com"
+    app_id: 200
+    read_write_timeout_ms: 5000
+`
+
+const testJson = `{
+  "profile": "dev",
+  "app_id": 100,
+  "access_key_id": "ak-default",
+  "secret_access_key": "sk#default",
+  "connect_timeout_ms": 500,
+  "profiles": {
+    "dev": {"endpoint": "http://127.0.0.1:8360"},
+    "prod": {"endpoint": "https://xasset.example.com", "app_id": 200, "read_write_timeout_ms": 5000}
+  }
+}`
+
+func writeTestFile(t *testing.T, name, content string) string {
+	dir, err := ioutil.TempDir("", "xasset-config")
+	if err != nil {
+		t.Fatalf("create temp dir failed.err:%v", err)
+	}
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("write file failed.err:%v", err)
+	}
+	return path
+}
+
+func envOf(kv map[string]string) func(string) (string, bool) {
+	return func(k string) (string, bool) {
+		v, ok := kv[k]
+		return v, ok
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	for _, f := range []struct{ name, content string }{{"xasset.yaml", testYaml}, {"xasset.json", testJson}} {
+		path := writeTestFile(t, f.name, f.content)
+		defer os.RemoveAll(filepath.Dir(path))
+
+		// 文件中指定的默认profile
+		cfg, err := Load(LoadOptions{File: path, LookupEnv: envOf(nil)})
+		if err != nil {
+			t.Fatalf("%s load failed.err:%v", f.name, err)
+		}
+		if cfg.Endpoint != "http://127.0.0.1:8360" || cfg.Credentials.AppId != 100 ||
+			cfg.Credentials.SecretAccessKey != "sk#default" || cfg.ConnectTimeoutMs != 500 ||
+			cfg.ReadWriteTimeoutMs != ReadWriteTimeoutMsDef {
+			t.Fatalf("%s dev profile error.%s", f.name, cfg)
+		}
+
+		// 环境变量选择profile并覆盖文件，Overrides优先级最高
+		sk := "sk-override"
+		cfg, err = Load(LoadOptions{
+			File:      path,
+			Overrides: Settings{SecretAccessKey: &sk},
+			LookupEnv: envOf(map[string]string{
+				EnvProfile:         "prod",
+				EnvAccessKeyId:     "ak-env",
+				EnvSecretAccessKey: "sk-env",
+			}),
+		})
+		if err != nil {
+			t.Fatalf("%s load failed.err:%v", f.name, err)
+		}
+		if cfg.Endpoint != "https://xasset.example.com" || cfg.Credentials.AppId != 200 ||
+			cfg.Credentials.AccessKeyId != "ak-env" || cfg.Credentials.SecretAccessKey != sk ||
+			cfg.ReadWriteTimeoutMs != 5000 {
+			t.Fatalf("%s prod profile error.%s", f.name, cfg)
+		}
+	}
+}
+
+func TestLoadEnvOnly(t *testing.T) {
+	cfg, err := Load(LoadOptions{LookupEnv: envOf(map[string]string{
+		EnvEndpoint:        "http://10.0.0.1:8360",
+		EnvAppId:           "300",
+		EnvAccessKeyId:     "ak",
+		EnvSecretAccessKey: "sk",
+	})})
+	if err != nil {
+		t.Fatalf("load failed.err:%v", err)
+	}
+	if cfg.Endpoint != "http://10.0.0.1:8360" || cfg.Credentials.AppId != 300 {
+		t.Fatalf("env config error.%s", cfg)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	path := writeTestFile(t, "bad.yaml", `endpont: http://127.0.0.1:8360
+app_id: abc
+connect_timeout_ms: -1
+profiles:
+  dev:
+    secret_key: x
+`)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	_, err := Load(LoadOptions{File: path, Profile: "sandbox", LookupEnv: envOf(map[string]string{
+		EnvReadWriteTimeoutMs: "3s",
+	})})
+	errs, ok := err.(Errors)
+	if !ok {
+		t.Fatalf("want Errors, got %v", err)
+	}
+	for _, want := range []string{
+		`unknown key "endpont"`,
+		"app_id must be an integer",
+		`profile "dev": unknown key "secret_key"`,
+		`profile "sandbox" not found`,
+		"XASSET_READ_WRITE_TIMEOUT_MS must be an integer",
+		"endpoint is required",
+		"app_id must be positive",
+		"access_key_id is required",
+		"secret_access_key is required",
+		"connect_timeout_ms must be positive",
+	} {
+		if !strings.Contains(errs.Error(), want) {
+			t.Fatalf("missing error %q in %v", want, errs)
+		}
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	path := writeTestFile(t, "bad.yaml", "a:\n  - 1\n")
+	defer os.RemoveAll(filepath.Dir(path))
+
+	for _, file := range []string{path, filepath.Join(filepath.Dir(path), "missing.yaml")} {
+		_, err := Load(LoadOptions{File: file, LookupEnv: envOf(map[string]string{
+			EnvAppId: "abc",
+		})})
+		errs, ok := err.(Errors)
+		if !ok || len(errs) < 3 {
+			t.Fatalf("want all errors, got %v", err)
+		}
+		for _, want := range []string{file, "XASSET_APP_ID must be an integer", "endpoint is required"} {
+			if !strings.Contains(errs.Error(), want) {
+				t.Fatalf("missing error %q in %v", want, errs)
+			}
+		}
+	}
+}
+
+func TestParseYaml(t *testing.T) {
+	for _, bad := range []string{
+		"a: 1\n  b: 2\n",
+		"a:\n  - 1\n",
+		"a: 1\na: 2\n",
+		"a:b\n",
+		"\ta: 1\n",
+		"a: [1, 2]\n",
+	} {
+		if _, err := parseYaml(bad); err == nil {
+			t.Fatalf("want error for %q", bad)
+		}
+	}
+
+	m, err := parseYaml("a:\nb:\n  c: 'it''s'\n  d: \"x\\ty\"\n")
+	if err != nil {
+		t.Fatalf("parse failed.err:%v", err)
+	}
+	b, _ := m["b"].(map[string]interface{})
+	if m["a"] != nil || b["c"] != "it's" || b["d"] != "x\ty" {
+		t.Fatalf("parse result error.%v", m)
+	}
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseYaml 解析配置文件使用的YAML子集：缩进表示的多层映射和标量值
+// 不支持列表、锚点、多行字符串等语法
+func parseYaml(data string) (map[string]interface{}, error) {
+	type level struct {
+		indent int
+		m      map[string]interface{}
+	}
+	root := make(map[string]interface{})
+	stack := []level{{indent: 0, m: root}}
+	// 上一行为"key:"时，下一行缩进更深则作为其子映射
+	var pendingKey string
+	pendingIndent := -1
+
+	for i, raw := range strings.Split(data, "\n") {
+		lineNo := i + 1
+		line := strings.TrimRight(stripYamlComment(raw), " \r")
+		if strings.TrimSpace(line) == "" || strings.TrimSpace(line) == "---" {
+			continue
+		}
+		trimmed := strings.TrimLeft(line, " ")
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, fmt.Errorf("line %d: tab indentation not allowed", lineNo)
+		}
+		indent := len(line) - len(trimmed)
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			return nil, fmt.Errorf("line %d: list not supported", lineNo)
+		}
+
+		if pendingKey != "" {
+			child := make(map[string]interface{})
+			if indent > pendingIndent {
+				stack[len(stack)-1].m[pendingKey] = child
+				stack = append(stack, level{indent: indent, m: child})
+			} else {
+				stack[len(stack)-1].m[pendingKey] = nil
+			}
+			pendingKey = ""
+		}
+		for len(stack) > 1 && indent < stack[len(stack)-1].indent {
+			stack = stack[:len(stack)-1]
+		}
+		if indent != stack[len(stack)-1].indent {
+			return nil, fmt.Errorf("line %d: bad indentation", lineNo)
+		}
+
+		pos := strings.Index(trimmed, ":")
+		if pos <= 0 || (pos+1 < len(trimmed) && trimmed[pos+1] != ' ') {
+			return nil, fmt.Errorf("line %d: expect key: value", lineNo)
+		}
+		key := strings.TrimSpace(trimmed[:pos])
+		if unquoted, err := yamlScalar(key); err == nil {
+			key = fmt.Sprint(unquoted)
+		}
+		cur := stack[len(stack)-1].m
+		if _, ok := cur[key]; ok {
+			return nil, fmt.Errorf("line %d: duplicated key %q", lineNo, key)
+		}
+
+		value := strings.TrimSpace(trimmed[pos+1:])
+		if value == "" {
+			pendingKey, pendingIndent = key, indent
+			// 先占位，便于检测重复key
+			cur[key] = nil
+			continue
+		}
+		v, err := yamlScalar(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNo, err)
+		}
+		cur[key] = v
+	}
+	return root, nil
+}
+
+// 去掉不在引号内的 # 注释
+func stripYamlComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func yamlScalar(s string) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(s, `"`):
+		return strconv.Unquote(s)
+	case strings.HasPrefix(s, "'"):
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return nil, fmt.Errorf("unterminated string %s", s)
+		}
+		return strings.Replace(s[1:len(s)-1], "''", "'", -1), nil
+	case strings.HasPrefix(s, "[") || strings.HasPrefix(s, "{") ||
+		strings.HasPrefix(s, "|") || strings.HasPrefix(s, ">"):
+		return nil, fmt.Errorf("unsupported value %s", s)
+	}
+	switch s {
+	case "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, nil
+	}
+	return s, nil
+}
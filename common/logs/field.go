@@ -0,0 +1,69 @@
+package logs
+
+import (
+	"strings"
+)
+
+// 日志级别
+type Level int
+
+const (
+	LevelTrace Level = iota
+	LevelDebug
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (t Level) String() string {
+	switch t {
+	case LevelTrace:
+		return "trace"
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	}
+	return "unknown"
+}
+
+// ParseLevel 解析级别名称，大小写不敏感
+func ParseLevel(s string) (Level, bool) {
+	for l := LevelTrace; l <= LevelError; l++ {
+		if strings.EqualFold(s, l.String()) {
+			return l, true
+		}
+	}
+	return LevelInfo, false
+}
+
+// 常用字段名，便于日志系统建立索引
+const (
+	FieldApi       = "api"
+	FieldUrl       = "url"
+	FieldAssetId   = "asset_id"
+	FieldShardId   = "shard_id"
+	FieldHttpCode  = "http_code"
+	FieldErrno     = "errno"
+	FieldRequestId = "request_id"
+	FieldTraceId   = "trace_id"
+	FieldLatency   = "latency"
+	FieldErr       = "err"
+	FieldBody      = "body"
+	FieldCaller    = "sdk_call"
+)
+
+// 日志字段
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// F 构造日志字段
+func F(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
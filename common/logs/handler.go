@@ -0,0 +1,158 @@
+package logs
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// 结构化日志处理接口，与log/slog的Handler类似
+type Handler interface {
+	Log(level Level, msg string, fields ...Field)
+}
+
+// 同时支持printf风格和结构化字段的日志驱动
+// Logger检测到驱动实现了Handler时，字段原样传给驱动，不再拼接到msg中
+type StructuredDriver interface {
+	LogDriver
+	Handler
+}
+
+// 键值对风格的日志接口，*slog.Logger等满足该接口
+type KVLogger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// NewStructuredDriver 将Handler包装为LogDriver，可直接传给NewAssetOperCli等构造函数
+func NewStructuredDriver(h Handler) StructuredDriver {
+	return &handlerDriver{Handler: h}
+}
+
+// NewStdDriver 以logfmt格式输出到标准库log，l为空时输出到标准错误
+func NewStdDriver(l *log.Logger) StructuredDriver {
+	if l == nil {
+		l = log.New(os.Stderr, "", log.LstdFlags)
+	}
+	return NewStructuredDriver(&stdHandler{l: l})
+}
+
+// NewKVDriver 以键值对参数输出到slog风格的日志库，trace级别按debug输出
+func NewKVDriver(l KVLogger) StructuredDriver {
+	return NewStructuredDriver(&kvHandler{l: l})
+}
+
+// NewDriverHandler 将字段按[key:value]拼接到msg后输出到已有的LogDriver
+func NewDriverHandler(d LogDriver) Handler {
+	return &driverHandler{d: d}
+}
+
+type handlerDriver struct {
+	Handler
+}
+
+func (t *handlerDriver) Error(msg string, ctx ...interface{}) {
+	t.Log(LevelError, sprintf(msg, ctx...))
+}
+
+func (t *handlerDriver) Warn(msg string, ctx ...interface{}) {
+	t.Log(LevelWarn, sprintf(msg, ctx...))
+}
+
+func (t *handlerDriver) Info(msg string, ctx ...interface{}) {
+	t.Log(LevelInfo, sprintf(msg, ctx...))
+}
+
+func (t *handlerDriver) Trace(msg string, ctx ...interface{}) {
+	t.Log(LevelTrace, sprintf(msg, ctx...))
+}
+
+func (t *handlerDriver) Debug(msg string, ctx ...interface{}) {
+	t.Log(LevelDebug, sprintf(msg, ctx...))
+}
+
+type stdHandler struct {
+	l *log.Logger
+}
+
+func (t *stdHandler) Log(level Level, msg string, fields ...Field) {
+	var b strings.Builder
+	b.WriteString("level=" + level.String() + " msg=" + logfmtValue(msg))
+	for _, f := range fields {
+		b.WriteString(" " + f.Key + "=" + logfmtValue(f.Value))
+	}
+	t.l.Print(b.String())
+}
+
+// 包含空白、引号或等号的值加引号
+func logfmtValue(v interface{}) string {
+	s := fmt.Sprint(v)
+	if s == "" || strings.ContainsAny(s, " \t\r\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+type kvHandler struct {
+	l KVLogger
+}
+
+func (t *kvHandler) Log(level Level, msg string, fields ...Field) {
+	args := make([]interface{}, 0, 2*len(fields))
+	for _, f := range fields {
+		args = append(args, f.Key, f.Value)
+	}
+	switch level {
+	case LevelError:
+		t.l.Error(msg, args...)
+	case LevelWarn:
+		t.l.Warn(msg, args...)
+	case LevelInfo:
+		t.l.Info(msg, args...)
+	default:
+		t.l.Debug(msg, args...)
+	}
+}
+
+type driverHandler struct {
+	d LogDriver
+}
+
+func (t *driverHandler) Log(level Level, msg string, fields ...Field) {
+	msg = joinFields(msg, fields)
+	switch level {
+	case LevelError:
+		t.d.Error(msg)
+	case LevelWarn:
+		t.d.Warn(msg)
+	case LevelInfo:
+		t.d.Info(msg)
+	case LevelDebug:
+		t.d.Debug(msg)
+	default:
+		t.d.Trace(msg)
+	}
+}
+
+func joinFields(msg string, fields []Field) string {
+	var b strings.Builder
+	b.WriteString(msg)
+	for _, f := range fields {
+		if b.Len() > 0 {
+			b.WriteString(" ")
+		}
+		fmt.Fprintf(&b, "[%s:%v]", f.Key, f.Value)
+	}
+	return b.String()
+}
+
+func sprintf(msg string, ctx ...interface{}) string {
+	if len(ctx) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, ctx...)
+}
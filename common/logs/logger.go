@@ -2,7 +2,9 @@ package logs
 
 import (
 	"fmt"
+	"reflect"
 
+	"github.com/xuperchain/xasset-sdk-go/common/redact"
 	"github.com/xuperchain/xasset-sdk-go/utils"
 )
 
@@ -17,63 +19,99 @@ type LogDriver interface {
 
 type Logger struct {
 	logDriver LogDriver
+	// logDriver实现了Handler时使用结构化输出
+	handler Handler
+	fields  []Field
 }
 
 func NewLogger(logDriver LogDriver) *Logger {
-	return &Logger{
+	logger := &Logger{
 		logDriver: logDriver,
 	}
+	if h, ok := logDriver.(Handler); ok {
+		logger.handler = h
+	}
+	return logger
 }
 
-func (t *Logger) Error(msg string, ctx ...interface{}) {
-	if t.logDriver == nil {
-		return
+// With 返回附带公共字段的Logger，原Logger不受影响
+func (t *Logger) With(fields ...Field) *Logger {
+	if t == nil {
+		return nil
 	}
+	res := *t
+	res.fields = make([]Field, 0, len(t.fields)+len(fields))
+	res.fields = append(res.fields, t.fields...)
+	res.fields = append(res.fields, fields...)
+	return &res
+}
 
-	t.logDriver.Error(t.fmtMsg(msg, ctx...))
+// Log 输出结构化日志，非结构化驱动按[key:value]拼接字段
+func (t *Logger) Log(level Level, msg string, fields ...Field) {
+	t.output(level, msg, fields)
 }
 
-func (t *Logger) Warn(msg string, ctx ...interface{}) {
-	if t.logDriver == nil {
-		return
-	}
+func (t *Logger) Error(msg string, ctx ...interface{}) {
+	t.output(LevelError, sprintf(msg, ctx...), nil)
+}
 
-	t.logDriver.Warn(t.fmtMsg(msg, ctx...))
+func (t *Logger) Warn(msg string, ctx ...interface{}) {
+	t.output(LevelWarn, sprintf(msg, ctx...), nil)
 }
 
 func (t *Logger) Info(msg string, ctx ...interface{}) {
-	if t.logDriver == nil {
-		return
-	}
-
-	t.logDriver.Info(t.fmtMsg(msg, ctx...))
+	t.output(LevelInfo, sprintf(msg, ctx...), nil)
 }
 
 func (t *Logger) Trace(msg string, ctx ...interface{}) {
-	if t.logDriver == nil {
-		return
-	}
-
-	t.logDriver.Trace(t.fmtMsg(msg, ctx...))
+	t.output(LevelTrace, sprintf(msg, ctx...), nil)
 }
 
 func (t *Logger) Debug(msg string, ctx ...interface{}) {
-	if t.logDriver == nil {
+	t.output(LevelDebug, sprintf(msg, ctx...), nil)
+}
+
+// 输出前清除私钥、SK、助记词等敏感信息
+func (t *Logger) output(level Level, msg string, fields []Field) {
+	if t == nil || t.logDriver == nil {
 		return
 	}
 
-	t.logDriver.Debug(t.fmtMsg(msg, ctx...))
+	all := make([]Field, 0, len(t.fields)+len(fields)+1)
+	all = append(all, t.fields...)
+	all = append(all, fields...)
+	all = append(all, t.callerField())
+	for i := range all {
+		all[i].Value = scrubValue(all[i].Key, all[i].Value)
+	}
+	msg = redact.Scrub(msg)
+
+	if t.handler != nil {
+		t.handler.Log(level, msg, all...)
+		return
+	}
+	NewDriverHandler(t.logDriver).Log(level, msg, all...)
 }
 
-func (t *Logger) genBaseMsg() string {
+func (t *Logger) callerField() Field {
 	call, _ := utils.GetFuncCall(4)
-	return fmt.Sprintf("[sdk_call:%s]", call)
+	return F(FieldCaller, call)
 }
 
-func (t *Logger) fmtMsg(msg string, ctx ...interface{}) string {
-	if msg == "" {
-		return t.genBaseMsg()
+func scrubValue(key string, value interface{}) interface{} {
+	if redact.IsSecretField(key) {
+		return redact.Mask
 	}
-
-	return fmt.Sprintf(msg+" "+t.genBaseMsg(), ctx...)
+	switch v := value.(type) {
+	case string:
+		return redact.Scrub(v)
+	case error:
+		return redact.Scrub(v.Error())
+	}
+	// 结构体等复合类型格式化后清除，如请求参数，基础类型保留原值便于结构化输出
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Struct, reflect.Ptr, reflect.Map, reflect.Slice, reflect.Array, reflect.Interface:
+		return redact.Scrub(fmt.Sprintf("%+v", value))
+	}
+	return value
 }
@@ -0,0 +1,94 @@
+package logs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+type printfDriver struct {
+	msgs []string
+}
+
+func (t *printfDriver) Error(msg string, ctx ...interface{}) { t.add("error", msg, ctx...) }
+func (t *printfDriver) Warn(msg string, ctx ...interface{})  { t.add("warn", msg, ctx...) }
+func (t *printfDriver) Info(msg string, ctx ...interface{})  { t.add("info", msg, ctx...) }
+func (t *printfDriver) Trace(msg string, ctx ...interface{}) { t.add("trace", msg, ctx...) }
+func (t *printfDriver) Debug(msg string, ctx ...interface{}) { t.add("debug", msg, ctx...) }
+
+func (t *printfDriver) add(lvl, msg string, ctx ...interface{}) {
+	t.msgs = append(t.msgs, lvl+" "+fmt.Sprintf(msg, ctx...))
+}
+
+type kvLogger struct {
+	level string
+	msg   string
+	args  []interface{}
+}
+
+func (t *kvLogger) Debug(msg string, args ...interface{}) { t.set("debug", msg, args) }
+func (t *kvLogger) Info(msg string, args ...interface{})  { t.set("info", msg, args) }
+func (t *kvLogger) Warn(msg string, args ...interface{})  { t.set("warn", msg, args) }
+func (t *kvLogger) Error(msg string, args ...interface{}) { t.set("error", msg, args) }
+
+func (t *kvLogger) set(level, msg string, args []interface{}) {
+	t.level, t.msg, t.args = level, msg, args
+}
+
+func TestLoggerPrintfDriver(t *testing.T) {
+	d := &printfDriver{}
+	logger := NewLogger(d)
+	logger.Warn("post failed.[url:%s]", "/x")
+	logger.With(F(FieldApi, "/api")).Log(LevelInfo, "done", F(FieldHttpCode, 200), F("sk", "s3cr3t"))
+
+	if len(d.msgs) != 2 {
+		t.Fatalf("want 2 msgs, got %d", len(d.msgs))
+	}
+	if !strings.HasPrefix(d.msgs[0], "warn post failed.[url:/x] [sdk_call:logger_test.go:") {
+		t.Fatalf("printf msg error: %s", d.msgs[0])
+	}
+	if !strings.HasPrefix(d.msgs[1], "info done [api:/api] [http_code:200] [sk:***] [sdk_call:logger_test.go:") {
+		t.Fatalf("field msg error: %s", d.msgs[1])
+	}
+}
+
+func TestLoggerStdDriver(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(NewStdDriver(log.New(&buf, "", 0)))
+	logger.Log(LevelWarn, "request finished", F(FieldApi, "/a"), F(FieldLatency, 15*time.Millisecond),
+		F(FieldErr, errors.New("read timeout")))
+
+	want := `level=warn msg="request finished" api=/a latency=15ms err="read timeout" sdk_call=logger_test.go:`
+	if !strings.HasPrefix(buf.String(), want) {
+		t.Fatalf("std output error: %s", buf.String())
+	}
+}
+
+func TestLoggerKVDriver(t *testing.T) {
+	l := &kvLogger{}
+	logger := NewLogger(NewKVDriver(l)).With(F(FieldTraceId, "t1"))
+	logger.Log(LevelTrace, "ok", F(FieldErrno, 0))
+	if l.level != "debug" || l.msg != "ok" || len(l.args) != 6 ||
+		l.args[0] != FieldTraceId || l.args[1] != "t1" || l.args[2] != FieldErrno || l.args[3] != 0 {
+		t.Fatalf("kv output error: %s %s %v", l.level, l.msg, l.args)
+	}
+
+	// printf风格调用只带调用位置字段
+	logger.Error("failed %d", 1)
+	if l.level != "error" || l.msg != "failed 1" || len(l.args) != 4 {
+		t.Fatalf("kv printf output error: %s %s %v", l.level, l.msg, l.args)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	if l, ok := ParseLevel("WARN"); !ok || l != LevelWarn {
+		t.Fatalf("parse level error")
+	}
+	if _, ok := ParseLevel("fatal"); ok {
+		t.Fatalf("want parse failed")
+	}
+}
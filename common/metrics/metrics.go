@@ -0,0 +1,138 @@
+// Package metrics SDK接口调用指标采集
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// 请求未收到响应时的http_code
+const HttpCodeNone = 0
+
+// 响应体不是json、无法解析errno时的取值
+const ErrnoUnknown = -1
+
+// 默认耗时分桶，单位秒
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// 指标采集接口，实现需要并发安全
+type Recorder interface {
+	// 请求开始发送
+	IncInFlight(api string)
+	// 请求结束
+	DecInFlight(api string)
+	// 记录一次请求的结果和耗时
+	ObserveRequest(api string, httpCode, errno int, latency time.Duration)
+}
+
+// 指标维度
+type Labels struct {
+	Api      string
+	HttpCode int
+	Errno    int
+}
+
+// 单个维度的请求统计
+type RequestStat struct {
+	Labels
+	Count uint64
+	// 耗时总和，单位秒
+	Sum float64
+	// 与Buckets对应的累计计数，即耗时<=上界的请求数
+	BucketCounts []uint64
+}
+
+// 内存实现，可通过WritePrometheus导出
+type Memory struct {
+	buckets  []float64
+	mu       sync.Mutex
+	requests map[Labels]*RequestStat
+	inFlight map[string]int64
+}
+
+// NewMemory 创建内存指标，buckets为空时使用DefBuckets
+func NewMemory(buckets []float64) *Memory {
+	if len(buckets) == 0 {
+		buckets = DefBuckets
+	}
+	b := append([]float64(nil), buckets...)
+	sort.Float64s(b)
+	return &Memory{
+		buckets:  b,
+		requests: make(map[Labels]*RequestStat),
+		inFlight: make(map[string]int64),
+	}
+}
+
+func (t *Memory) IncInFlight(api string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.inFlight[api]++
+}
+
+func (t *Memory) DecInFlight(api string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.inFlight[api]--
+}
+
+func (t *Memory) ObserveRequest(api string, httpCode, errno int, latency time.Duration) {
+	l := Labels{Api: api, HttpCode: httpCode, Errno: errno}
+	sec := latency.Seconds()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	stat, ok := t.requests[l]
+	if !ok {
+		stat = &RequestStat{Labels: l, BucketCounts: make([]uint64, len(t.buckets))}
+		t.requests[l] = stat
+	}
+	stat.Count++
+	stat.Sum += sec
+	for i, ub := range t.buckets {
+		if sec <= ub {
+			stat.BucketCounts[i]++
+		}
+	}
+}
+
+// Buckets 耗时分桶上界
+func (t *Memory) Buckets() []float64 {
+	return append([]float64(nil), t.buckets...)
+}
+
+// Requests 按api、http_code、errno排序的请求统计副本
+func (t *Memory) Requests() []RequestStat {
+	t.mu.Lock()
+	res := make([]RequestStat, 0, len(t.requests))
+	for _, stat := range t.requests {
+		s := *stat
+		s.BucketCounts = append([]uint64(nil), stat.BucketCounts...)
+		res = append(res, s)
+	}
+	t.mu.Unlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		a, b := res[i].Labels, res[j].Labels
+		if a.Api != b.Api {
+			return a.Api < b.Api
+		}
+		if a.HttpCode != b.HttpCode {
+			return a.HttpCode < b.HttpCode
+		}
+		return a.Errno < b.Errno
+	})
+	return res
+}
+
+// InFlight 各接口正在进行的请求数
+func (t *Memory) InFlight() map[string]int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	res := make(map[string]int64, len(t.inFlight))
+	for k, v := range t.inFlight {
+		res[k] = v
+	}
+	return res
+}
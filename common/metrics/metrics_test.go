@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMemoryRecorder(t *testing.T) {
+	m := NewMemory([]float64{0.1, 0.01})
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.IncInFlight("/a")
+			m.ObserveRequest("/a", 200, 0, 5*time.Millisecond)
+			m.DecInFlight("/a")
+		}()
+	}
+	wg.Wait()
+	m.ObserveRequest("/a", 200, 2001, 50*time.Millisecond)
+	m.ObserveRequest("/b", HttpCodeNone, ErrnoUnknown, time.Second)
+	m.IncInFlight("/b")
+
+	stats := m.Requests()
+	if len(stats) != 3 {
+		t.Fatalf("want 3 series, got %d", len(stats))
+	}
+	s := stats[0]
+	if s.Api != "/a" || s.Errno != 0 || s.Count != 50 || s.BucketCounts[0] != 50 || s.BucketCounts[1] != 50 {
+		t.Fatalf("series error.%+v", s)
+	}
+	if s := stats[1]; s.Errno != 2001 || s.BucketCounts[0] != 0 || s.BucketCounts[1] != 1 {
+		t.Fatalf("series error.%+v", s)
+	}
+	if in := m.InFlight(); in["/a"] != 0 || in["/b"] != 1 {
+		t.Fatalf("in flight error.%v", in)
+	}
+}
+
+func TestWritePrometheus(t *testing.T) {
+	m := NewMemory([]float64{0.1})
+	m.ObserveRequest("/xasset/horae/v1/grant", 200, 0, 20*time.Millisecond)
+	m.ObserveRequest(`/a"b`, 500, ErrnoUnknown, 2*time.Second)
+	m.IncInFlight("/xasset/horae/v1/grant")
+
+	var buf bytes.Buffer
+	if err := m.WritePrometheus(&buf); err != nil {
+		t.Fatalf("write failed.err:%v", err)
+	}
+	out := buf.String()
+	for _, line := range []string{
+		"# TYPE xasset_sdk_requests_total counter",
+		`xasset_sdk_requests_total{api="/xasset/horae/v1/grant",http_code="200",errno="0"} 1`,
+		`xasset_sdk_request_duration_seconds_bucket{api="/xasset/horae/v1/grant",http_code="200",errno="0",le="0.1"} 1`,
+		`xasset_sdk_request_duration_seconds_bucket{api="/a\"b",http_code="500",errno="-1",le="0.1"} 0`,
+		`xasset_sdk_request_duration_seconds_bucket{api="/a\"b",http_code="500",errno="-1",le="+Inf"} 1`,
+		`xasset_sdk_request_duration_seconds_sum{api="/a\"b",http_code="500",errno="-1"} 2`,
+		`xasset_sdk_request_duration_seconds_count{api="/xasset/horae/v1/grant",http_code="200",errno="0"} 1`,
+		"# TYPE xasset_sdk_requests_in_flight gauge",
+		`xasset_sdk_requests_in_flight{api="/xasset/horae/v1/grant"} 1`,
+	} {
+		if !strings.Contains(out, line+"\n") {
+			t.Fatalf("missing line %s\n%s", line, out)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Header().Get("Content-Type") != PrometheusContentType || rec.Body.String() != out {
+		t.Fatalf("handler output error")
+	}
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Prometheus文本格式指标名
+const (
+	MetricRequestsTotal   = "xasset_sdk_requests_total"
+	MetricRequestDuration = "xasset_sdk_request_duration_seconds"
+	MetricInFlight        = "xasset_sdk_requests_in_flight"
+)
+
+// Prometheus text exposition格式的Content-Type
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+// WritePrometheus 以Prometheus文本格式输出全部指标
+func (t *Memory) WritePrometheus(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	stats := t.Requests()
+	buckets := t.Buckets()
+
+	fmt.Fprintf(bw, "# HELP %s Total number of xasset api calls.\n", MetricRequestsTotal)
+	fmt.Fprintf(bw, "# TYPE %s counter\n", MetricRequestsTotal)
+	for _, s := range stats {
+		fmt.Fprintf(bw, "%s{%s} %d\n", MetricRequestsTotal, s.labelString(), s.Count)
+	}
+
+	fmt.Fprintf(bw, "# HELP %s Latency of xasset api calls.\n", MetricRequestDuration)
+	fmt.Fprintf(bw, "# TYPE %s histogram\n", MetricRequestDuration)
+	for _, s := range stats {
+		labels := s.labelString()
+		for i, ub := range buckets {
+			fmt.Fprintf(bw, "%s_bucket{%s,le=\"%s\"} %d\n", MetricRequestDuration, labels,
+				formatFloat(ub), s.BucketCounts[i])
+		}
+		fmt.Fprintf(bw, "%s_bucket{%s,le=\"+Inf\"} %d\n", MetricRequestDuration, labels, s.Count)
+		fmt.Fprintf(bw, "%s_sum{%s} %s\n", MetricRequestDuration, labels, formatFloat(s.Sum))
+		fmt.Fprintf(bw, "%s_count{%s} %d\n", MetricRequestDuration, labels, s.Count)
+	}
+
+	inFlight := t.InFlight()
+	apis := make([]string, 0, len(inFlight))
+	for api := range inFlight {
+		apis = append(apis, api)
+	}
+	sort.Strings(apis)
+	fmt.Fprintf(bw, "# HELP %s Number of xasset api calls in flight.\n", MetricInFlight)
+	fmt.Fprintf(bw, "# TYPE %s gauge\n", MetricInFlight)
+	for _, api := range apis {
+		fmt.Fprintf(bw, "%s{api=\"%s\"} %d\n", MetricInFlight, labelEscaper.Replace(api), inFlight[api])
+	}
+	return bw.Flush()
+}
+
+// Handler 返回/metrics接口的http.Handler
+func (t *Memory) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", PrometheusContentType)
+		t.WritePrometheus(w)
+	})
+}
+
+func (t *Labels) labelString() string {
+	return fmt.Sprintf(`api="%s",http_code="%d",errno="%d"`, labelEscaper.Replace(t.Api), t.HttpCode, t.Errno)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,63 @@
+// Package nonce 一次性随机数存储，用于签名防重放
+package nonce
+
+import (
+	"sync"
+	"time"
+)
+
+// 防重放存储
+type Store interface {
+	// Use 标记key已使用，expireAt之后记录可被清理
+	// 返回false表示key在有效期内已被使用过
+	Use(key string, expireAt time.Time) bool
+}
+
+// 进程内存储，多实例部署时需替换为共享存储实现
+type MemStore struct {
+	mu      sync.Mutex
+	entries map[string]time.Time
+	now     func() time.Time
+	lastGC  time.Time
+	// 清理过期记录的最小间隔
+	GCInterval time.Duration
+}
+
+func NewMemStore() *MemStore {
+	return &MemStore{
+		entries:    make(map[string]time.Time),
+		now:        time.Now,
+		GCInterval: time.Minute,
+	}
+}
+
+func (t *MemStore) Use(key string, expireAt time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	if now.Sub(t.lastGC) >= t.GCInterval {
+		t.gc(now)
+	}
+	if exp, ok := t.entries[key]; ok && now.Before(exp) {
+		return false
+	}
+	t.entries[key] = expireAt
+	return true
+}
+
+// 当前保存的记录数
+func (t *MemStore) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.entries)
+}
+
+func (t *MemStore) gc(now time.Time) {
+	for k, exp := range t.entries {
+		if !now.Before(exp) {
+			delete(t.entries, k)
+		}
+	}
+	t.lastGC = now
+}
@@ -0,0 +1,54 @@
+package nonce
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMemStore(t *testing.T) {
+	now := time.Unix(1000, 0)
+	s := NewMemStore()
+	s.now = func() time.Time { return now }
+
+	if !s.Use("a", now.Add(time.Minute)) {
+		t.Fatal("first use should succeed")
+	}
+	if s.Use("a", now.Add(time.Minute)) {
+		t.Fatal("replay should fail")
+	}
+
+	// 过期后记录被清理
+	now = now.Add(2 * time.Minute)
+	if !s.Use("b", now.Add(time.Minute)) || s.Len() != 1 {
+		t.Fatalf("expired entry should be removed.len:%d", s.Len())
+	}
+	if !s.Use("a", now.Add(time.Minute)) {
+		t.Fatal("expired key can be used again")
+	}
+}
+
+func TestMemStoreConcurrent(t *testing.T) {
+	s := NewMemStore()
+	exp := time.Now().Add(time.Minute)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succ := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if s.Use(fmt.Sprintf("k%d", i%5), exp) {
+				mu.Lock()
+				succ++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	if succ != 5 {
+		t.Fatalf("concurrent use count error.%d", succ)
+	}
+}
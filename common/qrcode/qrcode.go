@@ -0,0 +1,574 @@
+// Package qrcode 纯Go实现的二维码编码器，仅支持字节模式，输出PNG图片
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// 纠错等级
+type Level int
+
+const (
+	// 约7%纠错能力
+	LevelL Level = iota
+	// 约15%纠错能力
+	LevelM
+	// 约25%纠错能力
+	LevelQ
+	// 约30%纠错能力
+	LevelH
+)
+
+const (
+	MinVersion = 1
+	MaxVersion = 40
+	// 四周静区宽度，单位模块
+	QuietZone = 4
+)
+
+var (
+	ErrLevelInvalid = errors.New("qrcode error correction level invalid")
+	ErrDataTooLong  = errors.New("qrcode data too long")
+)
+
+// 各版本每块纠错码字数，下标为版本号
+var eccCodewordsPerBlock = [4][41]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+// 各版本纠错块数，下标为版本号
+var numErrorCorrectionBlocks = [4][41]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// 格式信息中的纠错等级编码
+var levelFormatBits = [4]int{1, 0, 3, 2}
+
+// 编码后的二维码
+type Code struct {
+	Version int
+	Level   Level
+	Mask    int
+	Size    int
+
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+// Encode 使用能容纳数据的最小版本编码，自动选择惩罚分最低的掩码
+func Encode(data []byte, level Level) (*Code, error) {
+	if level < LevelL || level > LevelH {
+		return nil, ErrLevelInvalid
+	}
+
+	version := MinVersion
+	for ; version <= MaxVersion; version++ {
+		if dataBitsNeeded(len(data), version) <= numDataCodewords(version, level)*8 {
+			break
+		}
+	}
+	if version > MaxVersion {
+		return nil, ErrDataTooLong
+	}
+
+	c := newCode(version, level)
+	codewords := c.addEccAndInterleave(c.dataCodewords(data))
+	c.drawFunctionPatterns()
+	c.drawCodewords(codewords)
+
+	bestMask, minPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		penalty := c.penaltyScore()
+		if minPenalty < 0 || penalty < minPenalty {
+			bestMask, minPenalty = mask, penalty
+		}
+		// 掩码为异或操作，再次应用即可还原
+		c.applyMask(mask)
+	}
+	c.Mask = bestMask
+	c.applyMask(bestMask)
+	c.drawFormatBits(bestMask)
+	return c, nil
+}
+
+// EncodeString 编码字符串
+func EncodeString(s string, level Level) (*Code, error) {
+	return Encode([]byte(s), level)
+}
+
+// Module 返回(x, y)处模块颜色，true为深色，越界返回false
+func (c *Code) Module(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+	return c.modules[y][x]
+}
+
+// Image 渲染为灰度图片，scale为每个模块的像素数，包含静区
+func (c *Code) Image(scale int) *image.Gray {
+	if scale < 1 {
+		scale = 1
+	}
+	dim := (c.Size + QuietZone*2) * scale
+	img := image.NewGray(image.Rect(0, 0, dim, dim))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if !c.modules[y][x] {
+				continue
+			}
+			px, py := (x+QuietZone)*scale, (y+QuietZone)*scale
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetGray(px+dx, py+dy, color.Gray{Y: 0})
+				}
+			}
+		}
+	}
+	return img
+}
+
+// PNG 渲染为PNG图片
+func (c *Code) PNG(scale int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, c.Image(scale)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func newCode(version int, level Level) *Code {
+	size := version*4 + 17
+	c := &Code{
+		Version:    version,
+		Level:      level,
+		Size:       size,
+		modules:    make([][]bool, size),
+		isFunction: make([][]bool, size),
+	}
+	for i := 0; i < size; i++ {
+		c.modules[i] = make([]bool, size)
+		c.isFunction[i] = make([]bool, size)
+	}
+	return c
+}
+
+// 字节模式下数据所需比特数
+func dataBitsNeeded(n, version int) int {
+	return 4 + charCountBits(version) + n*8
+}
+
+func charCountBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// 版本中除功能图形外可存放数据的模块数
+func numRawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		numAlign := version/7 + 2
+		result -= (25*numAlign-10)*numAlign - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+func numDataCodewords(version int, level Level) int {
+	return numRawDataModules(version)/8 -
+		eccCodewordsPerBlock[level][version]*numErrorCorrectionBlocks[level][version]
+}
+
+// 组装模式指示符、字符计数、数据、终止符和填充字节
+func (c *Code) dataCodewords(data []byte) []byte {
+	var bb bitBuffer
+	bb.append(0x4, 4)
+	bb.append(len(data), charCountBits(c.Version))
+	for _, b := range data {
+		bb.append(int(b), 8)
+	}
+
+	capacity := numDataCodewords(c.Version, c.Level) * 8
+	terminator := capacity - len(bb)
+	if terminator > 4 {
+		terminator = 4
+	}
+	bb.append(0, terminator)
+	bb.append(0, (8-len(bb)%8)%8)
+	for pad := 0xec; len(bb) < capacity; pad ^= 0xec ^ 0x11 {
+		bb.append(pad, 8)
+	}
+	return bb.bytes()
+}
+
+// 分块计算纠错码并交织
+func (c *Code) addEccAndInterleave(data []byte) []byte {
+	numBlocks := numErrorCorrectionBlocks[c.Level][c.Version]
+	blockEccLen := eccCodewordsPerBlock[c.Level][c.Version]
+	rawCodewords := numRawDataModules(c.Version) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	divisor := rsDivisor(blockEccLen)
+	blocks := make([][]byte, 0, numBlocks)
+	for i, k := 0, 0; i < numBlocks; i++ {
+		datLen := shortBlockLen - blockEccLen
+		if i >= numShortBlocks {
+			datLen++
+		}
+		dat := data[k : k+datLen]
+		k += datLen
+		block := make([]byte, 0, shortBlockLen+1)
+		block = append(block, dat...)
+		// 短块补一个占位字节，交织时跳过
+		if i < numShortBlocks {
+			block = append(block, 0)
+		}
+		block = append(block, rsRemainder(dat, divisor)...)
+		blocks = append(blocks, block)
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := 0; i <= shortBlockLen; i++ {
+		for j, block := range blocks {
+			if i != shortBlockLen-blockEccLen || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.isFunction[y][x] = true
+}
+
+func (c *Code) drawFunctionPatterns() {
+	// 定时图形
+	for i := 0; i < c.Size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	// 位置探测图形
+	c.drawFinder(3, 3)
+	c.drawFinder(c.Size-4, 3)
+	c.drawFinder(3, c.Size-4)
+
+	// 校正图形，跳过与位置探测图形重叠的三个角
+	pos := alignmentPositions(c.Version)
+	n := len(pos)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (i == 0 && j == 0) || (i == 0 && j == n-1) || (i == n-1 && j == 0) {
+				continue
+			}
+			c.drawAlignment(pos[i], pos[j])
+		}
+	}
+
+	// 先占位格式信息，再绘制版本信息
+	c.drawFormatBits(0)
+	c.drawVersion()
+}
+
+// 含分隔符的9*9区域
+func (c *Code) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || xx >= c.Size || yy < 0 || yy >= c.Size {
+				continue
+			}
+			dist := maxInt(absInt(dx), absInt(dy))
+			c.setFunction(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (c *Code) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunction(x+dx, y+dy, maxInt(absInt(dx), absInt(dy)) != 1)
+		}
+	}
+}
+
+func alignmentPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	numAlign := version/7 + 2
+	step := (version*8 + numAlign*3 + 5) / (numAlign*4 - 4) * 2
+	result := make([]int, numAlign)
+	result[0] = 6
+	for i, pos := numAlign-1, version*4+17-7; i >= 1; i, pos = i-1, pos-step {
+		result[i] = pos
+	}
+	return result
+}
+
+// 格式信息，BCH(15,5)编码后与0x5412异或
+func formatBits(level Level, mask int) int {
+	data := levelFormatBits[level]<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+func (c *Code) drawFormatBits(mask int) {
+	bits := formatBits(c.Level, mask)
+
+	// 左上角
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, getBit(bits, i))
+	}
+	c.setFunction(8, 7, getBit(bits, 6))
+	c.setFunction(8, 8, getBit(bits, 7))
+	c.setFunction(7, 8, getBit(bits, 8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, getBit(bits, i))
+	}
+
+	// 右上角和左下角
+	for i := 0; i < 8; i++ {
+		c.setFunction(c.Size-1-i, 8, getBit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.Size-15+i, getBit(bits, i))
+	}
+	// 固定深色模块
+	c.setFunction(8, c.Size-8, true)
+}
+
+// 版本信息，版本7及以上才有，BCH(18,6)编码
+func versionBits(version int) int {
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1f25)
+	}
+	return version<<12 | rem
+}
+
+func (c *Code) drawVersion() {
+	if c.Version < 7 {
+		return
+	}
+	bits := versionBits(c.Version)
+	for i := 0; i < 18; i++ {
+		bit := getBit(bits, i)
+		a, b := c.Size-11+i%3, i/3
+		c.setFunction(a, b, bit)
+		c.setFunction(b, a, bit)
+	}
+}
+
+// 从右下角开始按两列一组之字形填充数据
+func (c *Code) drawCodewords(data []byte) {
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if !c.isFunction[y][x] && i < len(data)*8 {
+					c.modules[y][x] = getBit(int(data[i>>3]), 7-(i&7))
+					i++
+				}
+			}
+		}
+	}
+}
+
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.isFunction[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert {
+				c.modules[y][x] = !c.modules[y][x]
+			}
+		}
+	}
+}
+
+// 掩码惩罚分，规则见GB/T 18284
+func (c *Code) penaltyScore() int {
+	size := c.Size
+	result := 0
+
+	// 规则1：行列中连续同色模块
+	for i := 0; i < size; i++ {
+		result += runPenalty(size, func(j int) bool { return c.modules[i][j] })
+		result += runPenalty(size, func(j int) bool { return c.modules[j][i] })
+	}
+
+	// 规则2：2*2同色块
+	for y := 0; y < size-1; y++ {
+		for x := 0; x < size-1; x++ {
+			v := c.modules[y][x]
+			if v == c.modules[y][x+1] && v == c.modules[y+1][x] && v == c.modules[y+1][x+1] {
+				result += 3
+			}
+		}
+	}
+
+	// 规则3：类似位置探测图形的1:1:3:1:1序列
+	for i := 0; i < size; i++ {
+		result += finderLikePenalty(size, func(j int) bool { return c.modules[i][j] })
+		result += finderLikePenalty(size, func(j int) bool { return c.modules[j][i] })
+	}
+
+	// 规则4：深色模块占比偏离50%
+	dark := 0
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if c.modules[y][x] {
+				dark++
+			}
+		}
+	}
+	total := size * size
+	k := (absInt(dark*20-total*10)+total-1)/total - 1
+	if k > 0 {
+		result += k * 10
+	}
+	return result
+}
+
+func runPenalty(size int, at func(int) bool) int {
+	result := 0
+	run := 1
+	for j := 1; j <= size; j++ {
+		if j < size && at(j) == at(j-1) {
+			run++
+			continue
+		}
+		if run >= 5 {
+			result += 3 + run - 5
+		}
+		run = 1
+	}
+	return result
+}
+
+var finderLike = []bool{true, false, true, true, true, false, true}
+
+func finderLikePenalty(size int, at func(int) bool) int {
+	// 越界视为浅色
+	get := func(j int) bool {
+		if j < 0 || j >= size {
+			return false
+		}
+		return at(j)
+	}
+	result := 0
+	for j := 0; j+len(finderLike) <= size; j++ {
+		match := true
+		for k, v := range finderLike {
+			if get(j+k) != v {
+				match = false
+				break
+			}
+		}
+		if !match {
+			continue
+		}
+		before, after := true, true
+		for k := 1; k <= 4; k++ {
+			if get(j - k) {
+				before = false
+			}
+			if get(j + len(finderLike) - 1 + k) {
+				after = false
+			}
+		}
+		if before || after {
+			result += 40
+		}
+	}
+	return result
+}
+
+func getBit(x, i int) bool {
+	return (x>>uint(i))&1 != 0
+}
+
+func absInt(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+type bitBuffer []bool
+
+func (b *bitBuffer) append(val, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*b = append(*b, getBit(val, i))
+	}
+}
+
+func (b bitBuffer) bytes() []byte {
+	out := make([]byte, (len(b)+7)/8)
+	for i, bit := range b {
+		if bit {
+			out[i>>3] |= 1 << uint(7-(i&7))
+		}
+	}
+	return out
+}
@@ -0,0 +1,255 @@
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestKnownVectors(t *testing.T) {
+	// M级、掩码0的格式信息
+	if v := formatBits(LevelM, 0); v != 0x5412 {
+		t.Fatalf("format bits error.%x", v)
+	}
+	if v := formatBits(LevelL, 4); v != 0x662f {
+		t.Fatalf("format bits error.%x", v)
+	}
+	if v := versionBits(7); v != 0x07c94 {
+		t.Fatalf("version bits error.%x", v)
+	}
+
+	// HELLO WORLD 1-M 的纠错码字
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	ecc := rsRemainder(data, rsDivisor(10))
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if !bytes.Equal(ecc, want) {
+		t.Fatalf("rs ecc error.%v", ecc)
+	}
+
+	cases := []struct {
+		version int
+		level   Level
+		want    int
+	}{
+		{1, LevelL, 19}, {1, LevelM, 16}, {1, LevelQ, 13}, {1, LevelH, 9},
+		{5, LevelQ, 62}, {5, LevelH, 46}, {10, LevelM, 216},
+		{40, LevelL, 2956}, {40, LevelH, 1276},
+	}
+	for _, c := range cases {
+		if n := numDataCodewords(c.version, c.level); n != c.want {
+			t.Fatalf("data codewords error.version:%d level:%d got:%d want:%d", c.version, c.level, n, c.want)
+		}
+	}
+
+	for version, want := range map[int][]int{
+		2: {6, 18}, 7: {6, 22, 38}, 32: {6, 34, 60, 86, 112, 138}, 40: {6, 30, 58, 86, 114, 142, 170},
+	} {
+		got := alignmentPositions(version)
+		if len(got) != len(want) {
+			t.Fatalf("alignment error.version:%d %v", version, got)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("alignment error.version:%d %v", version, got)
+			}
+		}
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	inputs := []string{
+		"",
+		"hello xasset",
+		strings.Repeat("票", 60),
+		strings.Repeat("0123456789abcdef", 40),
+	}
+	for _, in := range inputs {
+		for level := LevelL; level <= LevelH; level++ {
+			c, err := EncodeString(in, level)
+			if err != nil {
+				t.Fatalf("encode failed.len:%d level:%d err:%v", len(in), level, err)
+			}
+			out, err := decode(c)
+			if err != nil {
+				t.Fatalf("decode failed.version:%d level:%d mask:%d err:%v", c.Version, level, c.Mask, err)
+			}
+			if out != in {
+				t.Fatalf("round trip mismatch.version:%d level:%d", c.Version, level)
+			}
+		}
+	}
+
+	if _, err := Encode(make([]byte, 3000), LevelL); err != ErrDataTooLong {
+		t.Fatalf("want ErrDataTooLong, got %v", err)
+	}
+}
+
+func TestPNG(t *testing.T) {
+	c, err := EncodeString("https://xasset.baidu.com", LevelM)
+	if err != nil {
+		t.Fatalf("encode failed.err:%v", err)
+	}
+	data, err := c.PNG(3)
+	if err != nil {
+		t.Fatalf("render png failed.err:%v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode png failed.err:%v", err)
+	}
+	if img.Bounds().Dx() != (c.Size+QuietZone*2)*3 {
+		t.Fatalf("png size error.%v", img.Bounds())
+	}
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			r, _, _, _ := img.At((x+QuietZone)*3+1, (y+QuietZone)*3+1).RGBA()
+			if (r == 0) != c.Module(x, y) {
+				t.Fatalf("png module mismatch at (%d,%d)", x, y)
+			}
+		}
+	}
+}
+
+// 按标准流程从模块矩阵中解码，用于校验编码结果
+func decode(c *Code) (string, error) {
+	size := c.Size
+	version := (size - 17) / 4
+
+	// 格式信息两份拷贝需一致
+	var f1, f2 int
+	f1bits := [][2]int{{8, 0}, {8, 1}, {8, 2}, {8, 3}, {8, 4}, {8, 5}, {8, 7}, {8, 8}, {7, 8}, {5, 8}, {4, 8}, {3, 8}, {2, 8}, {1, 8}, {0, 8}}
+	for i, p := range f1bits {
+		if c.Module(p[0], p[1]) {
+			f1 |= 1 << uint(i)
+		}
+	}
+	for i := 0; i < 8; i++ {
+		if c.Module(size-1-i, 8) {
+			f2 |= 1 << uint(i)
+		}
+	}
+	for i := 8; i < 15; i++ {
+		if c.Module(8, size-15+i) {
+			f2 |= 1 << uint(i)
+		}
+	}
+	if f1 != f2 {
+		return "", errors.New("format copies differ")
+	}
+	level, mask := Level(-1), -1
+	for l := LevelL; l <= LevelH; l++ {
+		for m := 0; m < 8; m++ {
+			if formatBits(l, m) == f1 {
+				level, mask = l, m
+			}
+		}
+	}
+	if level != c.Level || mask != c.Mask {
+		return "", errors.New("format info error")
+	}
+	if !c.Module(8, size-8) {
+		return "", errors.New("dark module missing")
+	}
+
+	if version >= 7 {
+		var v int
+		for i := 0; i < 18; i++ {
+			if c.Module(size-11+i%3, i/3) {
+				v |= 1 << uint(i)
+			}
+		}
+		if v != versionBits(version) {
+			return "", errors.New("version info error")
+		}
+	}
+
+	// 根据版本重建功能区域，去除掩码后按之字形读出码字
+	ref := newCode(version, level)
+	ref.drawFunctionPatterns()
+	for y := 0; y < size; y++ {
+		copy(ref.modules[y], c.modules[y])
+	}
+	ref.applyMask(mask)
+
+	var raw []byte
+	var cur, n int
+	for right := size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < size; vert++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = size - 1 - vert
+				}
+				if ref.isFunction[y][x] {
+					continue
+				}
+				cur <<= 1
+				if ref.modules[y][x] {
+					cur |= 1
+				}
+				if n++; n%8 == 0 {
+					raw = append(raw, byte(cur))
+					cur = 0
+				}
+			}
+		}
+	}
+
+	// 解交织并校验每块的伴随式
+	numBlocks := numErrorCorrectionBlocks[level][version]
+	eccLen := eccCodewordsPerBlock[level][version]
+	rawLen := numRawDataModules(version) / 8
+	numShort := numBlocks - rawLen%numBlocks
+	shortLen := rawLen / numBlocks
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := 0; i <= shortLen; i++ {
+		for j := 0; j < numBlocks; j++ {
+			if i == shortLen-eccLen && j < numShort {
+				continue
+			}
+			blocks[j] = append(blocks[j], raw[k])
+			k++
+		}
+	}
+	var data []byte
+	for _, b := range blocks {
+		alpha := byte(1)
+		for i := 0; i < eccLen; i++ {
+			var s byte
+			for _, v := range b {
+				s = gfMultiply(s, alpha) ^ v
+			}
+			if s != 0 {
+				return "", errors.New("syndrome not zero")
+			}
+			alpha = gfMultiply(alpha, 2)
+		}
+		data = append(data, b[:len(b)-eccLen]...)
+	}
+
+	// 字节模式
+	bit := func(i int) int { return int(data[i/8]>>uint(7-i%8)) & 1 }
+	read := func(pos, n int) int {
+		v := 0
+		for i := 0; i < n; i++ {
+			v = v<<1 | bit(pos+i)
+		}
+		return v
+	}
+	if read(0, 4) != 4 {
+		return "", errors.New("mode error")
+	}
+	count := read(4, charCountBits(version))
+	pos := 4 + charCountBits(version)
+	out := make([]byte, count)
+	for i := range out {
+		out[i] = byte(read(pos+i*8, 8))
+	}
+	return string(out), nil
+}
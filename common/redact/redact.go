@@ -0,0 +1,133 @@
+// Package redact 日志和String()输出中的敏感信息脱敏
+package redact
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/xuperchain/crypto/core/hdwallet/wordlist"
+)
+
+// 脱敏后的占位符
+const Mask = "***"
+
+// 助记词最少单词数
+const minMnemonicWords = 12
+
+// 按字段名脱敏的敏感字段，大小写不敏感
+// union_id等用户标识由调用方用Partial保留部分字符，不在此列
+var SecretFields = []string{
+	"secret_access_key",
+	"secretaccesskey",
+	"sk",
+	"session_token",
+	"sessiontoken",
+	"private_key",
+	"privatekey",
+	"privtkey",
+	"mnemonic",
+	"token",
+}
+
+var (
+	// 形如 name: value、name=value、"name":"value"，引号内的值支持转义，其他值截止到空白或分隔符
+	fieldRegexp = regexp.MustCompile(`(?i)(\\?"?\b(?:` + strings.Join(SecretFields, "|") +
+		`)\b\\?"?\s*[:=]\s*)("(?:[^"\\]|\\.)*"|\\"(?:[^"\\]|\\[^"])*\\"|[^\s\]\[,}&"]+)`)
+	// json格式私钥中的D，包括被转义后嵌入其他json的情况
+	privDRegexp = regexp.MustCompile(`(\\?"D\\?"\s*:\s*)\d+`)
+)
+
+// Secret 完全脱敏，空值保持为空便于判断是否设置
+func Secret(s string) string {
+	if s == "" {
+		return ""
+	}
+	return Mask
+}
+
+// Partial 保留首尾少量字符，用于union_id等需要辅助排查的标识
+func Partial(s string) string {
+	r := []rune(s)
+	if len(r) <= 8 {
+		return Secret(s)
+	}
+	return string(r[:3]) + Mask + string(r[len(r)-3:])
+}
+
+// IsSecretField 字段名是否属于敏感字段
+func IsSecretField(name string) bool {
+	for _, f := range SecretFields {
+		if strings.EqualFold(f, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Scrub 清除文本中的助记词、json私钥和敏感字段值
+func Scrub(msg string) string {
+	msg = scrubMnemonic(msg)
+	msg = privDRegexp.ReplaceAllString(msg, "${1}"+Mask)
+	return fieldRegexp.ReplaceAllStringFunc(msg, scrubField)
+}
+
+func scrubField(m string) string {
+	sub := fieldRegexp.FindStringSubmatch(m)
+	val := sub[2]
+	if strings.HasPrefix(val, `\"`) {
+		return sub[1] + `\"` + Mask + `\"`
+	}
+	if strings.HasPrefix(val, `"`) {
+		return sub[1] + `"` + Mask + `"`
+	}
+	return sub[1] + Mask
+}
+
+// 连续12个以上助记词词表中的单词视为助记词，整体替换为一个占位符
+// 保留首个单词前的字段名、引号和末个单词后的标点
+func scrubMnemonic(msg string) string {
+	tokens := strings.Split(msg, " ")
+	res := make([]string, 0, len(tokens))
+	run := 0
+	for i := 0; i <= len(tokens); i++ {
+		if i < len(tokens) && isMnemonicWord(tokens[i]) {
+			run++
+			continue
+		}
+		if run >= minMnemonicWords {
+			prefix, _, _ := splitWord(tokens[i-run])
+			_, _, suffix := splitWord(tokens[i-1])
+			res = append(res, prefix+Mask+suffix)
+		} else {
+			res = append(res, tokens[i-run:i]...)
+		}
+		run = 0
+		if i < len(tokens) {
+			res = append(res, tokens[i])
+		}
+	}
+	return strings.Join(res, " ")
+}
+
+func isMnemonicWord(tok string) bool {
+	_, word, _ := splitWord(tok)
+	if _, ok := wordlist.ReversedEnglishWordMap[word]; ok {
+		return true
+	}
+	_, ok := wordlist.ReversedSimplifiedChineseWordMap[word]
+	return ok
+}
+
+// 拆出 {Mnemonic:、引号等前后缀
+func splitWord(tok string) (prefix, word, suffix string) {
+	start := strings.LastIndexAny(tok, ":=") + 1
+	rest := tok[start:]
+	start += len(rest) - len(strings.TrimLeftFunc(rest, notLetter))
+	word = strings.TrimRightFunc(tok[start:], notLetter)
+	return tok[:start], word, tok[start+len(word):]
+}
+
+func notLetter(r rune) bool {
+	return !unicode.IsLetter(r)
+}
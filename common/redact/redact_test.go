@@ -0,0 +1,45 @@
+package redact
+
+import (
+	"strings"
+	"testing"
+)
+
+const testMnemonic = "abandon ability able about above absent absorb abstract absurd abuse access accident"
+
+func TestScrub(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{"[sk: abc123] [url: x]", "[sk: ***] [url: x]"},
+		{"AppId:1 AccessKeyId:ak SecretAccessKey:s3cr3t", "AppId:1 AccessKeyId:ak SecretAccessKey:***"},
+		{`{"secret_access_key":"s3cr3t","bucket":"b"}`, `{"secret_access_key":"***","bucket":"b"}`},
+		{"union_id=u123&mnemonic=m456", "union_id=u123&mnemonic=***"},
+		{`{"Curvname":"P-256","X":1,"Y":2,"D":12345}`, `{"Curvname":"P-256","X":1,"Y":2,"D":***}`},
+		{`"private_key":"{\"Curvname\":\"P-256\",\"D\":12345}"`, `"private_key":"***"`},
+		{`{\"mnemonic\":\"a b\",\"sk\":\"x\"}`, `{\"mnemonic\":\"***\",\"sk\":\"***\"}`},
+		{"mnemonic: " + testMnemonic + " [url: x]", "mnemonic: *** [url: x]"},
+		{"param: {Mnemonic:" + testMnemonic + "}", "param: {Mnemonic:***}"},
+		{"task: ok asset_id: 1", "task: ok asset_id: 1"},
+		{"abandon ability able", "abandon ability able"},
+	}
+	for _, c := range cases {
+		if got := Scrub(c.in); got != c.want {
+			t.Fatalf("scrub %q\n got %q\nwant %q", c.in, got, c.want)
+		}
+	}
+
+	cn := strings.Repeat("泊 ", 12)
+	if got := Scrub("m " + cn + "x"); strings.Contains(got, "泊") {
+		t.Fatalf("chinese mnemonic not scrubbed.%q", got)
+	}
+}
+
+func TestSecret(t *testing.T) {
+	if Secret("") != "" || Secret("x") != Mask {
+		t.Fatalf("secret error")
+	}
+	if Partial("abc") != Mask || Partial("0123456789") != "012***789" {
+		t.Fatalf("partial error.%s", Partial("0123456789"))
+	}
+}
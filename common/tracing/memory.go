@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// 已结束span的记录
+type SpanData struct {
+	Name       string
+	Context    SpanContext
+	Parent     SpanContext
+	Attributes map[string]interface{}
+	Err        error
+	Start      time.Time
+	End        time.Time
+}
+
+// 内存追踪器，记录已结束的span，用于测试或自行导出
+type MemoryTracer struct {
+	mu    sync.Mutex
+	spans []SpanData
+}
+
+func NewMemoryTracer() *MemoryTracer {
+	return &MemoryTracer{}
+}
+
+func (t *MemoryTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &memorySpan{
+		tracer: t,
+		data: SpanData{
+			Name:       name,
+			Attributes: make(map[string]interface{}),
+			Start:      time.Now(),
+		},
+	}
+	if parent, ok := SpanContextFromContext(ctx); ok {
+		span.data.Parent = parent
+		span.data.Context.TraceId = parent.TraceId
+		span.data.Context.Flags = parent.Flags
+	} else {
+		randRead(span.data.Context.TraceId[:])
+		span.data.Context.Flags = FlagSampled
+	}
+	randRead(span.data.Context.SpanId[:])
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return ContextWithSpanContext(ctx, span.data.Context), span
+}
+
+// Spans 已结束span的副本
+func (t *MemoryTracer) Spans() []SpanData {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]SpanData(nil), t.spans...)
+}
+
+type memorySpan struct {
+	tracer *MemoryTracer
+	mu     sync.Mutex
+	data   SpanData
+	ended  bool
+}
+
+func (t *memorySpan) SpanContext() SpanContext {
+	return t.data.Context
+}
+
+func (t *memorySpan) SetAttribute(key string, value interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.data.Attributes[key] = value
+}
+
+func (t *memorySpan) RecordError(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.data.Err = err
+}
+
+func (t *memorySpan) End() {
+	t.mu.Lock()
+	if t.ended {
+		t.mu.Unlock()
+		return
+	}
+	t.ended = true
+	t.data.End = time.Now()
+	data := t.data
+	t.mu.Unlock()
+
+	t.tracer.mu.Lock()
+	t.tracer.spans = append(t.tracer.spans, data)
+	t.tracer.mu.Unlock()
+}
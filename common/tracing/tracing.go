@@ -0,0 +1,114 @@
+// Package tracing SDK调用的分布式追踪，接口与具体追踪系统无关
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// W3C Trace Context请求头
+const HeaderTraceparent = "traceparent"
+
+// span属性名
+const (
+	AttrApi           = "xasset.api"
+	AttrAssetId       = "xasset.asset_id"
+	AttrShardId       = "xasset.shard_id"
+	AttrErrno         = "xasset.errno"
+	AttrRequestId     = "xasset.request_id"
+	AttrServerTraceId = "xasset.server_trace_id"
+	AttrHttpCode      = "http.status_code"
+)
+
+// traceparent中的采样标记
+const FlagSampled byte = 0x01
+
+var ErrTraceparent = errors.New("traceparent invalid")
+
+type TraceId [16]byte
+
+type SpanId [8]byte
+
+func (t TraceId) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+func (t SpanId) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// 跨进程传递的span标识
+type SpanContext struct {
+	TraceId TraceId
+	SpanId  SpanId
+	Flags   byte
+}
+
+// IsValid trace_id和span_id均不为全零
+func (t SpanContext) IsValid() bool {
+	return t.TraceId != TraceId{} && t.SpanId != SpanId{}
+}
+
+// Traceparent 按W3C格式编码，如 00-<trace_id>-<span_id>-01
+func (t SpanContext) Traceparent() string {
+	return "00-" + t.TraceId.String() + "-" + t.SpanId.String() + "-" + hex.EncodeToString([]byte{t.Flags})
+}
+
+// ParseTraceparent 解析W3C traceparent，只支持00版本
+func ParseTraceparent(s string) (SpanContext, error) {
+	var sc SpanContext
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) != 4 || parts[0] != "00" || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return sc, ErrTraceparent
+	}
+	if _, err := hex.Decode(sc.TraceId[:], []byte(parts[1])); err != nil {
+		return sc, ErrTraceparent
+	}
+	if _, err := hex.Decode(sc.SpanId[:], []byte(parts[2])); err != nil {
+		return sc, ErrTraceparent
+	}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil || !sc.IsValid() {
+		return sc, ErrTraceparent
+	}
+	sc.Flags = flags[0]
+	return sc, nil
+}
+
+// 追踪器接口，可适配OpenTelemetry等实现
+type Tracer interface {
+	// 开始一个span，父span从ctx中获取，返回的ctx包含新span
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// 一次调用对应的span
+type Span interface {
+	SpanContext() SpanContext
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpanContext 将上游的span标识放入ctx，SDK调用会作为其子span
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// SpanContextFromContext 读取ctx中的span标识
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	if ctx == nil {
+		return SpanContext{}, false
+	}
+	sc, ok := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc, ok && sc.IsValid()
+}
+
+func randRead(b []byte) {
+	// 随机数不可用时退化为全零id，IsValid会拒绝传播
+	rand.Read(b)
+}
@@ -0,0 +1,57 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTraceparent(t *testing.T) {
+	tp := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, err := ParseTraceparent(tp)
+	if err != nil {
+		t.Fatalf("parse failed.err:%v", err)
+	}
+	if sc.TraceId.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.Flags != FlagSampled || sc.Traceparent() != tp {
+		t.Fatalf("traceparent round trip error.%s", sc.Traceparent())
+	}
+
+	for _, s := range []string{
+		"",
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e473z-00f067aa0ba902b7-01",
+	} {
+		if _, err := ParseTraceparent(s); err != ErrTraceparent {
+			t.Fatalf("want ErrTraceparent for %q, got %v", s, err)
+		}
+	}
+}
+
+func TestMemoryTracer(t *testing.T) {
+	parent, _ := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	tracer := NewMemoryTracer()
+	ctx, span := tracer.Start(ContextWithSpanContext(context.Background(), parent), "child")
+	span.SetAttribute(AttrApi, "/a")
+	span.End()
+	span.End()
+
+	spans := tracer.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("want 1 span, got %d", len(spans))
+	}
+	s := spans[0]
+	if s.Context.TraceId != parent.TraceId || s.Parent != parent || s.Context.SpanId == parent.SpanId ||
+		s.Attributes[AttrApi] != "/a" {
+		t.Fatalf("span error.%+v", s)
+	}
+	if sc, ok := SpanContextFromContext(ctx); !ok || sc != s.Context {
+		t.Fatalf("ctx should carry new span")
+	}
+
+	// 没有父span时生成新的trace_id
+	_, root := tracer.Start(context.Background(), "root")
+	if !root.SpanContext().IsValid() || root.SpanContext().TraceId == parent.TraceId {
+		t.Fatalf("root span error")
+	}
+}
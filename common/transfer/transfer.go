@@ -0,0 +1,214 @@
+package transfer
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// 传输进度
+type Progress struct {
+	// 已发送字节数
+	Sent int64 `json:"sent"`
+	// 总字节数，未知时为-1
+	Total int64 `json:"total"`
+	// 平均发送速率，单位：字节/秒
+	Rate float64 `json:"rate"`
+}
+
+// 进度回调，每次有数据发送后调用
+type ProgressFunc func(p Progress)
+
+// Limiter 带宽限制器（令牌桶），并发安全
+// 多个上传共用同一个Limiter时，限制的是它们的总带宽
+type Limiter struct {
+	mu     sync.Mutex
+	rate   int64
+	tokens float64
+	last   time.Time
+}
+
+// 新建带宽限制器
+// @bytesPerSec: 每秒允许发送的最大字节数，必须大于0
+func NewLimiter(bytesPerSec int64) *Limiter {
+	if bytesPerSec <= 0 {
+		return nil
+	}
+
+	return &Limiter{
+		rate:   bytesPerSec,
+		tokens: float64(bytesPerSec),
+		last:   time.Now(),
+	}
+}
+
+// 单次最多可申请的字节数
+func (t *Limiter) Burst() int {
+	if t == nil {
+		return 0
+	}
+	return int(t.rate)
+}
+
+// WaitN 申请发送n个字节，令牌不足时阻塞到可发送为止
+func (t *Limiter) WaitN(n int) {
+	if t == nil || n <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	now := time.Now()
+	t.tokens += now.Sub(t.last).Seconds() * float64(t.rate)
+	if t.tokens > float64(t.rate) {
+		t.tokens = float64(t.rate)
+	}
+	t.last = now
+	t.tokens -= float64(n)
+	deficit := t.tokens
+	t.mu.Unlock()
+
+	if deficit < 0 {
+		time.Sleep(time.Duration(-deficit / float64(t.rate) * float64(time.Second)))
+	}
+}
+
+// Reader 包装上传数据流，统计发送进度并按需限速
+type Reader struct {
+	rc       io.ReadCloser
+	total    int64
+	sent     int64
+	start    time.Time
+	limiter  *Limiter
+	progress ProgressFunc
+}
+
+// 新建进度统计Reader
+// @total: 数据总长度，未知时传-1
+// @limiter: 可选，为nil时不限速
+// @progress: 可选，为nil时不回调
+func NewReader(rc io.ReadCloser, total int64, limiter *Limiter, progress ProgressFunc) *Reader {
+	return &Reader{
+		rc:       rc,
+		total:    total,
+		limiter:  limiter,
+		progress: progress,
+	}
+}
+
+func (t *Reader) Read(p []byte) (int, error) {
+	if t.start.IsZero() {
+		t.start = time.Now()
+	}
+	if burst := t.limiter.Burst(); burst > 0 && len(p) > burst {
+		p = p[:burst]
+	}
+
+	n, err := t.rc.Read(p)
+	if n > 0 {
+		t.limiter.WaitN(n)
+		t.sent += int64(n)
+		if t.progress != nil {
+			t.progress(t.Progress())
+		}
+	}
+	return n, err
+}
+
+func (t *Reader) Close() error {
+	return t.rc.Close()
+}
+
+// 当前进度
+func (t *Reader) Progress() Progress {
+	return Progress{
+		Sent:  t.sent,
+		Total: t.total,
+		Rate:  calcRate(t.sent, t.start),
+	}
+}
+
+// 汇总状态
+type GroupStatus struct {
+	// 跟踪的任务数
+	Tasks int `json:"tasks"`
+	// 已完成的任务数
+	Done int `json:"done"`
+	// 所有任务已发送字节数
+	Sent int64 `json:"sent"`
+	// 所有任务总字节数
+	Total int64 `json:"total"`
+	// 整体平均发送速率，单位：字节/秒
+	Rate float64 `json:"rate"`
+}
+
+// Group 汇总多个并发上传的进度，并发安全
+type Group struct {
+	mu       sync.Mutex
+	start    time.Time
+	tasks    map[string]Progress
+	onChange func(s GroupStatus)
+}
+
+// 新建进度汇总
+// @onChange: 可选，任一任务进度变化时回调汇总状态
+func NewGroup(onChange func(s GroupStatus)) *Group {
+	return &Group{
+		start:    time.Now(),
+		tasks:    make(map[string]Progress),
+		onChange: onChange,
+	}
+}
+
+// Track 登记一个上传任务，返回的回调用于UploadFileParam.Progress
+// @name: 任务名称，同名任务会被覆盖
+// @total: 任务总字节数，未知时传-1，首次回调后以回调值为准
+func (t *Group) Track(name string, total int64) ProgressFunc {
+	t.mu.Lock()
+	t.tasks[name] = Progress{Total: total}
+	t.mu.Unlock()
+
+	return func(p Progress) {
+		t.mu.Lock()
+		t.tasks[name] = p
+		status := t.status()
+		t.mu.Unlock()
+
+		if t.onChange != nil {
+			t.onChange(status)
+		}
+	}
+}
+
+// 当前汇总状态
+func (t *Group) Status() GroupStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.status()
+}
+
+func (t *Group) status() GroupStatus {
+	s := GroupStatus{Tasks: len(t.tasks)}
+	for _, p := range t.tasks {
+		s.Sent += p.Sent
+		if p.Total > 0 {
+			s.Total += p.Total
+			if p.Sent >= p.Total {
+				s.Done++
+			}
+		}
+	}
+	s.Rate = calcRate(s.Sent, t.start)
+	return s
+}
+
+func calcRate(sent int64, start time.Time) float64 {
+	if start.IsZero() {
+		return 0
+	}
+	elapsed := time.Since(start).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(sent) / elapsed
+}
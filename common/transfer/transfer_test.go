@@ -0,0 +1,82 @@
+package transfer
+
+import (
+	"bytes"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReaderProgress(t *testing.T) {
+	data := bytes.Repeat([]byte("x"), 100*1024)
+
+	var last Progress
+	calls := 0
+	r := NewReader(ioutil.NopCloser(bytes.NewReader(data)), int64(len(data)), nil, func(p Progress) {
+		last = p
+		calls++
+	})
+	out, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read failed.err:%v", err)
+	}
+	if len(out) != len(data) {
+		t.Fatalf("read length mismatch. want:%d got:%d", len(data), len(out))
+	}
+	if calls == 0 || last.Sent != int64(len(data)) || last.Total != int64(len(data)) {
+		t.Fatalf("progress error. calls:%d last:%+v", calls, last)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	// 首个burst立即可用，剩余数据按限速发送
+	data := bytes.Repeat([]byte("x"), 3000)
+	r := NewReader(ioutil.NopCloser(bytes.NewReader(data)), int64(len(data)), NewLimiter(2000), nil)
+
+	begin := time.Now()
+	if _, err := ioutil.ReadAll(r); err != nil {
+		t.Fatalf("read failed.err:%v", err)
+	}
+	cost := time.Since(begin)
+	if cost < 400*time.Millisecond {
+		t.Fatalf("limiter not work. cost:%v", cost)
+	}
+
+	if NewLimiter(0) != nil {
+		t.Fatal("zero limiter should be nil")
+	}
+}
+
+func TestGroup(t *testing.T) {
+	var mu sync.Mutex
+	var last GroupStatus
+	g := NewGroup(func(s GroupStatus) {
+		mu.Lock()
+		last = s
+		mu.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	for _, name := range []string{"a.jpg", "b.jpg", "c.jpg"} {
+		data := bytes.Repeat([]byte("x"), 4096)
+		fn := g.Track(name, int64(len(data)))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := NewReader(ioutil.NopCloser(bytes.NewReader(data)), int64(len(data)), nil, fn)
+			ioutil.ReadAll(r)
+		}()
+	}
+	wg.Wait()
+
+	s := g.Status()
+	if s.Tasks != 3 || s.Done != 3 || s.Sent != 3*4096 || s.Total != 3*4096 {
+		t.Fatalf("group status error.status:%+v", s)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if last.Sent <= 0 || last.Sent > s.Sent {
+		t.Fatalf("group callback error.last:%+v", last)
+	}
+}
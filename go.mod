@@ -6,4 +6,5 @@ require (
 	github.com/baidubce/bce-sdk-go v0.9.112
 	github.com/spf13/cobra v1.0.0
 	github.com/xuperchain/crypto v0.0.0-20211224062819-eca101aeda3f
+	golang.org/x/crypto v0.0.0-20210322153248-0c34fe9e7dc2
 )
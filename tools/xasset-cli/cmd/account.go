@@ -27,6 +27,13 @@ func GetAccountCmd() *AccountCmd {
 
 	cmdIns.Cmd.AddCommand(GetCreateAccCmd().GetCmd())
 	cmdIns.Cmd.AddCommand(GetRetrieveAccCmd().GetCmd())
+	cmdIns.Cmd.AddCommand(GetImportAccCmd().GetCmd())
+	cmdIns.Cmd.AddCommand(GetExportAccCmd().GetCmd())
+	cmdIns.Cmd.AddCommand(GetListAccCmd().GetCmd())
+	cmdIns.Cmd.AddCommand(GetPasswdAccCmd().GetCmd())
+	cmdIns.Cmd.AddCommand(GetSplitAccCmd().GetCmd())
+	cmdIns.Cmd.AddCommand(GetCombineAccCmd().GetCmd())
+	cmdIns.Cmd.AddCommand(GetConvertAccCmd().GetCmd())
 
 	return cmdIns
 }
@@ -37,6 +44,7 @@ type CreateAccountCmd struct {
 	// 要绑定的变量类型只能使用内置基础类型
 	Strgth int
 	Lang   int
+	Crypto string
 	Fmt    string
 }
 
@@ -57,6 +65,7 @@ func GetCreateAccCmd() *CreateAccountCmd {
 	// 设置命令行参数并绑定变量
 	cmdIns.Cmd.Flags().IntVarP(&cmdIns.Strgth, "strgth", "s", 1, "mnemonic words strength. 1|2|3")
 	cmdIns.Cmd.Flags().IntVarP(&cmdIns.Lang, "lang", "l", 1, "mnemonic words language. 1|2")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Crypto, "crypto", "c", "nist", "crypto type. nist|gm")
 	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Fmt, "fmt", "f", "vis", "display format. std|vis")
 
 	return cmdIns
@@ -64,7 +73,12 @@ func GetCreateAccCmd() *CreateAccountCmd {
 
 // print new account
 func (t *CreateAccountCmd) CreateAccount() error {
-	acc, err := auth.NewXchainEcdsaAccount(auth.MnemStrgth(t.Strgth), auth.MnemLang(t.Lang))
+	ct, err := auth.ParseCryptoType(t.Crypto)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	acc, err := auth.NewAccount(ct, auth.MnemStrgth(t.Strgth), auth.MnemLang(t.Lang))
 	if err != nil {
 		fmt.Print(common.FailedRespMsg)
 		return nil
@@ -105,6 +119,7 @@ type RetrieveAccountCmd struct {
 	// 要绑定的变量类型只能使用内置基础类型
 	Mnemonic string
 	Lang     int
+	Crypto   string
 	Fmt      string
 }
 
@@ -125,6 +140,7 @@ func GetRetrieveAccCmd() *RetrieveAccountCmd {
 	// 设置命令行参数并绑定变量
 	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Mnemonic, "mnemonic", "m", "", "mnemonic words")
 	cmdIns.Cmd.Flags().IntVarP(&cmdIns.Lang, "lang", "l", 1, "mnemonic words language. 1|2")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Crypto, "crypto", "c", "nist", "crypto type. nist|gm")
 	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Fmt, "fmt", "f", "vis", "display format. std|vis")
 
 	return cmdIns
@@ -132,7 +148,12 @@ func GetRetrieveAccCmd() *RetrieveAccountCmd {
 
 // print retrieve account
 func (t *RetrieveAccountCmd) RetrieveAccount() error {
-	acc, err := auth.RetrieveAccountByMnemonic(t.Mnemonic, t.Lang)
+	ct, err := auth.ParseCryptoType(t.Crypto)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	acc, err := auth.RetrieveAccount(ct, t.Mnemonic, t.Lang)
 	if err != nil {
 		fmt.Print(common.FailedRespMsg)
 		return nil
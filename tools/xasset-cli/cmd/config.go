@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/xuperchain/xasset-sdk-go/common/config"
+	"github.com/xuperchain/xasset-sdk-go/tools/xasset-cli/common"
+
+	"github.com/spf13/cobra"
+)
+
+// 全局配置参数，所有需要访问服务端的命令共用
+type ConfigFlags struct {
+	File    string
+	Profile string
+}
+
+var configFlags ConfigFlags
+
+// BindConfigFlags 在根命令上注册--config和--profile
+func BindConfigFlags(root *cobra.Command) {
+	root.PersistentFlags().StringVar(&configFlags.File, "config", "",
+		"config file, yaml or json. default $"+config.EnvConfig)
+	root.PersistentFlags().StringVar(&configFlags.Profile, "profile", "",
+		"profile in config file. default $"+config.EnvProfile)
+}
+
+// 按 配置文件 < profile < 环境变量 < 命令行参数 加载配置
+func loadConfig(overrides config.Settings) (*config.XassetCliConfig, error) {
+	return config.Load(config.LoadOptions{
+		File:      configFlags.File,
+		Profile:   configFlags.Profile,
+		Overrides: overrides,
+	})
+}
+
+// show config command
+type ConfigCmd struct {
+	BaseCmd
+}
+
+func GetConfigCmd() *ConfigCmd {
+	cmdIns := new(ConfigCmd)
+
+	cmdIns.Cmd = &cobra.Command{
+		Use:           "config",
+		Short:         "Show the resolved config with secrets masked.",
+		Example:       common.CmdLineName + " config --config xasset.yaml --profile prod",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmdIns.Show()
+		},
+	}
+
+	return cmdIns
+}
+
+// 校验失败时输出全部错误，便于修正配置
+func (t *ConfigCmd) Show() error {
+	cfg, err := loadConfig(config.Settings{})
+	if err != nil {
+		fmt.Println(err)
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	fmt.Println(cfg)
+	return nil
+}
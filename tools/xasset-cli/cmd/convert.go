@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	"github.com/xuperchain/xasset-sdk-go/tools/xasset-cli/common"
+
+	"github.com/spf13/cobra"
+)
+
+// convert key format command
+type ConvertAccountCmd struct {
+	BaseCmd
+	// 要绑定的变量类型只能使用内置基础类型
+	Key    string
+	File   string
+	From   string
+	To     string
+	Public bool
+	Crypto string
+}
+
+func GetConvertAccCmd() *ConvertAccountCmd {
+	cmdIns := new(ConvertAccountCmd)
+
+	cmdIns.Cmd = &cobra.Command{
+		Use:           "convert",
+		Short:         "Convert key between json, pem, hex and jwk formats.",
+		Example:       common.CmdLineName + " account convert -i key.pem --from pem --to json",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmdIns.Convert()
+		},
+	}
+
+	// 设置命令行参数并绑定变量
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Key, "key", "k", "", "key content")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.File, "file", "i", "", "file containing the key")
+	cmdIns.Cmd.Flags().StringVar(&cmdIns.From, "from", "json", "input format. json|pem|pkcs8|sec1|hex|jwk")
+	cmdIns.Cmd.Flags().StringVar(&cmdIns.To, "to", "pkcs8", "output format. json|pem|pkcs8|sec1|hex|jwk")
+	cmdIns.Cmd.Flags().BoolVar(&cmdIns.Public, "public", false, "convert public key instead of private key")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Crypto, "crypto", "c", "nist", "curve of hex input. nist|gm")
+
+	return cmdIns
+}
+
+// print converted key, json output prints the account with address
+func (t *ConvertAccountCmd) Convert() error {
+	data := t.Key
+	if t.File != "" {
+		content, err := ioutil.ReadFile(t.File)
+		if err != nil {
+			fmt.Print(common.FailedRespMsg)
+			return nil
+		}
+		data = string(content)
+	}
+	ct, err := auth.ParseCryptoType(t.Crypto)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+
+	var acc *auth.Account
+	if t.Public {
+		acc, err = auth.ImportPublicKey(data, auth.KeyFormat(t.From), ct)
+	} else {
+		acc, err = auth.ImportPrivateKey(data, auth.KeyFormat(t.From), ct)
+	}
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+
+	var out string
+	switch {
+	case auth.KeyFormat(t.To) == auth.KeyFormatJson:
+		js, err := json.Marshal(acc)
+		if err != nil {
+			fmt.Print(common.FailedRespMsg)
+			return nil
+		}
+		out = string(js)
+	case t.Public:
+		out, err = auth.ExportPublicKey(acc.PublicKey, auth.KeyFormat(t.To))
+	default:
+		out, err = auth.ExportPrivateKey(acc.PrivateKey, auth.KeyFormat(t.To))
+	}
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+
+	fmt.Println(strings.TrimSpace(out))
+	return nil
+}
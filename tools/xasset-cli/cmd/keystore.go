@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	"github.com/xuperchain/xasset-sdk-go/auth/keystore"
+	"github.com/xuperchain/xasset-sdk-go/tools/xasset-cli/common"
+
+	"github.com/spf13/cobra"
+)
+
+// 默认密钥库目录
+func defaultKeystoreDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return common.DefaultKeystoreDir
+	}
+	return filepath.Join(home, common.DefaultKeystoreDir)
+}
+
+// import account command
+type ImportAccountCmd struct {
+	BaseCmd
+	// 要绑定的变量类型只能使用内置基础类型
+	Dir      string
+	Mnemonic string
+	Lang     int
+	Crypto   string
+	File     string
+	PassFile string
+}
+
+func GetImportAccCmd() *ImportAccountCmd {
+	cmdIns := new(ImportAccountCmd)
+
+	cmdIns.Cmd = &cobra.Command{
+		Use:           "import",
+		Short:         "Import account into encrypted keystore.",
+		Example:       common.CmdLineName + " account import -m 'your mnemonic' -l 1 -p pass.txt",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmdIns.Import()
+		},
+	}
+
+	// 设置命令行参数并绑定变量
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Dir, "keystore", "d", defaultKeystoreDir(), "keystore directory")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Mnemonic, "mnemonic", "m", "", "mnemonic words")
+	cmdIns.Cmd.Flags().IntVarP(&cmdIns.Lang, "lang", "l", 1, "mnemonic words language. 1|2")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Crypto, "crypto", "c", "nist", "crypto type of mnemonic. nist|gm")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.File, "file", "i", "", "account json file printed by 'account create -f std'")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.PassFile, "passfile", "p", "", "file containing the passphrase")
+
+	return cmdIns
+}
+
+func (t *ImportAccountCmd) Import() error {
+	var acc *auth.Account
+	var err error
+	switch {
+	case t.Mnemonic != "":
+		var ct auth.CryptoType
+		if ct, err = auth.ParseCryptoType(t.Crypto); err == nil {
+			acc, err = auth.RetrieveAccount(ct, t.Mnemonic, t.Lang)
+		}
+	case t.File != "":
+		acc, err = loadAccountFile(t.File)
+	default:
+		err = fmt.Errorf("mnemonic or file required")
+	}
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+
+	ks, err := keystore.New(t.Dir, nil)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	pass, err := keystore.ReadPassphrase(t.PassFile, "passphrase: ")
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	entry, err := ks.Import(acc, pass)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+
+	fmt.Printf("address:%s\n", entry.Address)
+	fmt.Printf("path:%s\n", entry.Path)
+	return nil
+}
+
+func loadAccountFile(path string) (*auth.Account, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var acc auth.Account
+	if err := json.Unmarshal(data, &acc); err != nil {
+		return nil, err
+	}
+	if acc.Address == "" || acc.PrivateKey == "" {
+		return nil, fmt.Errorf("account file invalid")
+	}
+	return &acc, nil
+}
+
+// export account command
+type ExportAccountCmd struct {
+	BaseCmd
+	// 要绑定的变量类型只能使用内置基础类型
+	Dir      string
+	Addr     string
+	PassFile string
+	Fmt      string
+}
+
+func GetExportAccCmd() *ExportAccountCmd {
+	cmdIns := new(ExportAccountCmd)
+
+	cmdIns.Cmd = &cobra.Command{
+		Use:           "export",
+		Short:         "Export decrypted account from keystore to stdout.",
+		Example:       common.CmdLineName + " account export -a [address] -p pass.txt -f std",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmdIns.Export()
+		},
+	}
+
+	// 设置命令行参数并绑定变量
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Dir, "keystore", "d", defaultKeystoreDir(), "keystore directory")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Addr, "addr", "a", "", "account address")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.PassFile, "passfile", "p", "", "file containing the passphrase")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Fmt, "fmt", "f", "vis", "display format. std|vis")
+
+	return cmdIns
+}
+
+func (t *ExportAccountCmd) Export() error {
+	ks, err := keystore.New(t.Dir, nil)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	pass, err := keystore.ReadPassphrase(t.PassFile, "passphrase: ")
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	acc, err := ks.Load(t.Addr, pass)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+
+	switch t.Fmt {
+	case "std":
+		js, err := json.Marshal(acc)
+		if err != nil {
+			fmt.Print(common.FailedRespMsg)
+			return nil
+		}
+		fmt.Print(string(js))
+	case "vis":
+		fmt.Printf("address:%s\n", acc.Address)
+		fmt.Printf("private_key:%s\n", acc.PrivateKey)
+		fmt.Printf("public_key:%s\n", acc.PublicKey)
+		fmt.Printf("mnemonic:%s\n", acc.Mnemonic)
+	default:
+		fmt.Print(common.FailedRespMsg)
+	}
+
+	return nil
+}
+
+// list keystore command
+type ListAccountCmd struct {
+	BaseCmd
+	// 要绑定的变量类型只能使用内置基础类型
+	Dir string
+}
+
+func GetListAccCmd() *ListAccountCmd {
+	cmdIns := new(ListAccountCmd)
+
+	cmdIns.Cmd = &cobra.Command{
+		Use:           "list",
+		Short:         "List accounts in keystore.",
+		Example:       common.CmdLineName + " account list -d ~/.xasset/keystore",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmdIns.List()
+		},
+	}
+
+	// 设置命令行参数并绑定变量
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Dir, "keystore", "d", defaultKeystoreDir(), "keystore directory")
+
+	return cmdIns
+}
+
+func (t *ListAccountCmd) List() error {
+	ks, err := keystore.New(t.Dir, nil)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	entries, err := ks.List()
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	for _, e := range entries {
+		fmt.Printf("%s\t%s\n", e.Address, e.Path)
+	}
+	return nil
+}
+
+// change passphrase command
+type PasswdAccountCmd struct {
+	BaseCmd
+	// 要绑定的变量类型只能使用内置基础类型
+	Dir         string
+	Addr        string
+	PassFile    string
+	NewPassFile string
+}
+
+func GetPasswdAccCmd() *PasswdAccountCmd {
+	cmdIns := new(PasswdAccountCmd)
+
+	cmdIns.Cmd = &cobra.Command{
+		Use:           "passwd",
+		Short:         "Change keystore passphrase of an account.",
+		Example:       common.CmdLineName + " account passwd -a [address] -p old.txt -n new.txt",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmdIns.Passwd()
+		},
+	}
+
+	// 设置命令行参数并绑定变量
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Dir, "keystore", "d", defaultKeystoreDir(), "keystore directory")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Addr, "addr", "a", "", "account address")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.PassFile, "passfile", "p", "", "file containing the current passphrase")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.NewPassFile, "newpassfile", "n", "", "file containing the new passphrase")
+
+	return cmdIns
+}
+
+func (t *PasswdAccountCmd) Passwd() error {
+	ks, err := keystore.New(t.Dir, nil)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	oldPass, err := keystore.ReadPassphrase(t.PassFile, "current passphrase: ")
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	if t.NewPassFile == "" {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	newPass, err := keystore.ReadPassphrase(t.NewPassFile, "")
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	if err := ks.ChangePassphrase(t.Addr, oldPass, newPass); err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	fmt.Printf("address:%s\n", t.Addr)
+	return nil
+}
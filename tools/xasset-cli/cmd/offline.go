@@ -0,0 +1,343 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	"github.com/xuperchain/xasset-sdk-go/auth/keystore"
+	xbase "github.com/xuperchain/xasset-sdk-go/client/base"
+	"github.com/xuperchain/xasset-sdk-go/client/xasset"
+	"github.com/xuperchain/xasset-sdk-go/common/config"
+	"github.com/xuperchain/xasset-sdk-go/tools/xasset-cli/common"
+
+	"github.com/spf13/cobra"
+)
+
+type OfflineCmd struct {
+	BaseCmd
+}
+
+func GetOfflineCmd() *OfflineCmd {
+	cmdIns := new(OfflineCmd)
+
+	cmdIns.Cmd = &cobra.Command{
+		Use:           "offline",
+		Short:         "Prepare, sign and submit asset operations with an air-gapped key.",
+		Example:       common.CmdLineName + " offline prepare|sign|submit [arguments]",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmdIns.Cmd.AddCommand(GetPrepareOfflineCmd().GetCmd())
+	cmdIns.Cmd.AddCommand(GetSignOfflineCmd().GetCmd())
+	cmdIns.Cmd.AddCommand(GetSubmitOfflineCmd().GetCmd())
+
+	return cmdIns
+}
+
+// 输出到文件或标准输出
+func writeOutput(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+func readInput(path string, v interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// prepare offline request command
+type PrepareOfflineCmd struct {
+	BaseCmd
+	// 要绑定的变量类型只能使用内置基础类型
+	Op         string
+	AppId      int64
+	Addr       string
+	AssetId    int64
+	ShardId    int64
+	Price      int64
+	Amount     int
+	Info       string
+	IsEvidence int
+	ToAddr     string
+	ToUserId   int64
+	Out        string
+}
+
+func GetPrepareOfflineCmd() *PrepareOfflineCmd {
+	cmdIns := new(PrepareOfflineCmd)
+
+	cmdIns.Cmd = &cobra.Command{
+		Use:           "prepare",
+		Short:         "Prepare an unsigned request on the online machine.",
+		Example:       common.CmdLineName + " offline prepare --op grant -a [addr] --asset [asset_id] --to [to_addr] -o unsigned.json",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmdIns.Prepare()
+		},
+	}
+
+	// 设置命令行参数并绑定变量
+	cmdIns.Cmd.Flags().StringVar(&cmdIns.Op, "op", "", "operation. create|publish|grant|transfer")
+	cmdIns.Cmd.Flags().Int64Var(&cmdIns.AppId, "appid", 0, "app id, used to generate asset id when creating")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Addr, "addr", "a", "", "signer address")
+	cmdIns.Cmd.Flags().Int64Var(&cmdIns.AssetId, "asset", 0, "asset id")
+	cmdIns.Cmd.Flags().Int64Var(&cmdIns.ShardId, "shard", 0, "shard id")
+	cmdIns.Cmd.Flags().Int64Var(&cmdIns.Price, "price", 0, "price")
+	cmdIns.Cmd.Flags().IntVar(&cmdIns.Amount, "amount", 0, "asset amount when creating")
+	cmdIns.Cmd.Flags().StringVar(&cmdIns.Info, "info", "", "asset info json when creating")
+	cmdIns.Cmd.Flags().IntVar(&cmdIns.IsEvidence, "evidence", 0, "whether to evidence when publishing. 0|1")
+	cmdIns.Cmd.Flags().StringVar(&cmdIns.ToAddr, "to", "", "receiver address")
+	cmdIns.Cmd.Flags().Int64Var(&cmdIns.ToUserId, "touid", 0, "receiver user id")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Out, "out", "o", "", "output file, default stdout")
+
+	return cmdIns
+}
+
+func (t *PrepareOfflineCmd) Prepare() error {
+	// 准备阶段不访问网络，只需app id
+	cfg := config.NewXassetCliConf()
+	cfg.SetCredentials(t.AppId, "", "")
+	oper, err := xasset.NewAssetOperCli(cfg, nil)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+
+	account := auth.AddrOnly(t.Addr)
+	var req *xbase.UnsignedReq
+	switch xbase.OfflineOp(t.Op) {
+	case xbase.OfflineOpCreate:
+		var info xbase.CreateAssetInfo
+		if err = json.Unmarshal([]byte(t.Info), &info); err != nil {
+			break
+		}
+		req, err = oper.PrepareCreateAsset(&xbase.CreateAssetParam{
+			AssetId:   t.AssetId,
+			Price:     t.Price,
+			Amount:    t.Amount,
+			AssetInfo: &info,
+			Account:   account,
+		})
+	case xbase.OfflineOpPublish:
+		req, err = oper.PreparePublishAsset(&xbase.PublishAssetParam{
+			AssetId:    t.AssetId,
+			Account:    account,
+			IsEvidence: t.IsEvidence,
+		})
+	case xbase.OfflineOpGrant:
+		req, err = oper.PrepareGrantAsset(&xbase.GrantAssetParam{
+			AssetId:  t.AssetId,
+			ShardId:  t.ShardId,
+			Price:    t.Price,
+			Account:  account,
+			Addr:     t.Addr,
+			ToAddr:   t.ToAddr,
+			ToUserId: t.ToUserId,
+		})
+	case xbase.OfflineOpTransfer:
+		req, err = oper.PrepareTransferAsset(&xbase.TransferAssetParam{
+			AssetId:  t.AssetId,
+			ShardId:  t.ShardId,
+			Price:    t.Price,
+			Account:  account,
+			Addr:     t.Addr,
+			ToAddr:   t.ToAddr,
+			ToUserId: t.ToUserId,
+		})
+	default:
+		err = xbase.ErrOfflineOp
+	}
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+
+	if err := writeOutput(t.Out, req); err != nil {
+		fmt.Print(common.FailedRespMsg)
+	}
+	return nil
+}
+
+// sign offline request command
+type SignOfflineCmd struct {
+	BaseCmd
+	// 要绑定的变量类型只能使用内置基础类型
+	In       string
+	Out      string
+	Dir      string
+	PassFile string
+}
+
+func GetSignOfflineCmd() *SignOfflineCmd {
+	cmdIns := new(SignOfflineCmd)
+
+	cmdIns.Cmd = &cobra.Command{
+		Use:           "sign",
+		Short:         "Sign an unsigned request with a keystore account on the offline machine.",
+		Example:       common.CmdLineName + " offline sign -i unsigned.json -o signed.json -p pass.txt",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmdIns.Sign()
+		},
+	}
+
+	// 设置命令行参数并绑定变量
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.In, "in", "i", "", "unsigned request file")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Out, "out", "o", "", "output file, default stdout")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Dir, "keystore", "d", defaultKeystoreDir(), "keystore directory")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.PassFile, "passfile", "p", "", "file containing the passphrase")
+
+	return cmdIns
+}
+
+func (t *SignOfflineCmd) Sign() error {
+	var req xbase.UnsignedReq
+	if err := readInput(t.In, &req); err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	if err := req.Valid(); err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	// 平台签名不覆盖请求字段，签名前展示给操作者核对
+	fmt.Fprint(os.Stderr, req.Summary())
+
+	ks, err := keystore.New(t.Dir, nil)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	pass, err := keystore.ReadPassphrase(t.PassFile, "passphrase: ")
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	acc, err := ks.Load(req.Addr, pass)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	signed, err := req.Sign(acc)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+
+	if err := writeOutput(t.Out, signed); err != nil {
+		fmt.Print(common.FailedRespMsg)
+	}
+	return nil
+}
+
+// submit signed request command
+type SubmitOfflineCmd struct {
+	BaseCmd
+	// 要绑定的变量类型只能使用内置基础类型
+	In       string
+	Endpoint string
+	AppId    int64
+	AK       string
+	SK       string
+}
+
+func GetSubmitOfflineCmd() *SubmitOfflineCmd {
+	cmdIns := new(SubmitOfflineCmd)
+
+	cmdIns.Cmd = &cobra.Command{
+		Use:           "submit",
+		Short:         "Submit a signed request on the online machine.",
+		Example:       common.CmdLineName + " offline submit -i signed.json --config xasset.yaml --profile prod",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmdIns.Submit()
+		},
+	}
+
+	// 设置命令行参数并绑定变量
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.In, "in", "i", "", "signed request file")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Endpoint, "endpoint", "e", "", "xasset endpoint, overrides config")
+	cmdIns.Cmd.Flags().Int64Var(&cmdIns.AppId, "appid", 0, "app id, overrides config")
+	cmdIns.Cmd.Flags().StringVar(&cmdIns.AK, "ak", "", "access key, overrides config")
+	cmdIns.Cmd.Flags().StringVar(&cmdIns.SK, "sk", "", "secret key, overrides config")
+
+	return cmdIns
+}
+
+func (t *SubmitOfflineCmd) Submit() error {
+	var req xbase.SignedReq
+	if err := readInput(t.In, &req); err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+
+	// 只有显式指定的命令行参数覆盖配置
+	var overrides config.Settings
+	flags := t.Cmd.Flags()
+	if flags.Changed("endpoint") {
+		overrides.Endpoint = &t.Endpoint
+	}
+	if flags.Changed("appid") {
+		overrides.AppId = &t.AppId
+	}
+	if flags.Changed("ak") {
+		overrides.AccessKeyId = &t.AK
+	}
+	if flags.Changed("sk") {
+		overrides.SecretAccessKey = &t.SK
+	}
+	cfg, err := loadConfig(overrides)
+	if err != nil {
+		fmt.Println(err)
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	oper, err := xasset.NewAssetOperCli(cfg, nil)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+
+	var resp interface{}
+	switch req.Op {
+	case xbase.OfflineOpCreate:
+		resp, _, err = oper.SubmitCreateAsset(&req)
+	case xbase.OfflineOpPublish:
+		resp, _, err = oper.SubmitPublishAsset(&req)
+	case xbase.OfflineOpGrant:
+		resp, _, err = oper.SubmitGrantAsset(&req)
+	case xbase.OfflineOpTransfer:
+		resp, _, err = oper.SubmitTransferAsset(&req)
+	default:
+		err = xbase.ErrOfflineOp
+	}
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+
+	js, err := json.Marshal(resp)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	fmt.Print(string(js))
+	return nil
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	"github.com/xuperchain/xasset-sdk-go/tools/xasset-cli/common"
+
+	"github.com/spf13/cobra"
+)
+
+// split mnemonic command
+type SplitAccountCmd struct {
+	BaseCmd
+	// 要绑定的变量类型只能使用内置基础类型
+	Mnemonic  string
+	Lang      int
+	Crypto    string
+	Total     int
+	Threshold int
+}
+
+func GetSplitAccCmd() *SplitAccountCmd {
+	cmdIns := new(SplitAccountCmd)
+
+	cmdIns.Cmd = &cobra.Command{
+		Use:           "split",
+		Short:         "Split mnemonic into shares, any threshold of them can recover the account.",
+		Example:       common.CmdLineName + " account split -m 'your mnemonic' -l 1 -n 5 -k 3",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmdIns.Split()
+		},
+	}
+
+	// 设置命令行参数并绑定变量
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Mnemonic, "mnemonic", "m", "", "mnemonic words")
+	cmdIns.Cmd.Flags().IntVarP(&cmdIns.Lang, "lang", "l", 1, "mnemonic words language. 1|2")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Crypto, "crypto", "c", "nist", "crypto type. nist|gm")
+	cmdIns.Cmd.Flags().IntVarP(&cmdIns.Total, "total", "n", 3, "number of shares")
+	cmdIns.Cmd.Flags().IntVarP(&cmdIns.Threshold, "threshold", "k", 2, "shares required to recover")
+
+	return cmdIns
+}
+
+// print one share per line
+func (t *SplitAccountCmd) Split() error {
+	// 拆分前确认助记词可以恢复账户，避免备份无效的助记词
+	ct, err := auth.ParseCryptoType(t.Crypto)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	acc, err := auth.RetrieveAccount(ct, t.Mnemonic, t.Lang)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	shares, err := auth.SplitMnemonic(acc.Mnemonic, t.Total, t.Threshold)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+
+	fmt.Printf("address:%s\n", acc.Address)
+	for _, s := range shares {
+		fmt.Println(s)
+	}
+	return nil
+}
+
+// combine shares command
+type CombineAccountCmd struct {
+	BaseCmd
+	// 要绑定的变量类型只能使用内置基础类型
+	Shares []string
+	Lang   int
+	Crypto string
+	Fmt    string
+}
+
+func GetCombineAccCmd() *CombineAccountCmd {
+	cmdIns := new(CombineAccountCmd)
+
+	cmdIns.Cmd = &cobra.Command{
+		Use:           "combine",
+		Short:         "Recover account from mnemonic shares.",
+		Example:       common.CmdLineName + " account combine -s [share1] -s [share2] -l 1 -f vis",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmdIns.Combine()
+		},
+	}
+
+	// 设置命令行参数并绑定变量
+	cmdIns.Cmd.Flags().StringArrayVarP(&cmdIns.Shares, "share", "s", nil, "mnemonic share, repeat for each share")
+	cmdIns.Cmd.Flags().IntVarP(&cmdIns.Lang, "lang", "l", 1, "mnemonic words language. 1|2")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Crypto, "crypto", "c", "nist", "crypto type. nist|gm")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Fmt, "fmt", "f", "vis", "display format. std|vis")
+
+	return cmdIns
+}
+
+// print recovered account
+func (t *CombineAccountCmd) Combine() error {
+	ct, err := auth.ParseCryptoType(t.Crypto)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	acc, err := auth.RetrieveAccountByShares(ct, t.Shares, t.Lang)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+
+	switch t.Fmt {
+	case "std":
+		js, err := json.Marshal(acc)
+		if err != nil {
+			fmt.Print(common.FailedRespMsg)
+			return nil
+		}
+		fmt.Print(string(js))
+	case "vis":
+		fmt.Printf("address:%s\n", acc.Address)
+		fmt.Printf("private_key:%s\n", acc.PrivateKey)
+		fmt.Printf("public_key:%s\n", acc.PublicKey)
+		fmt.Printf("mnemonic:%s\n", acc.Mnemonic)
+	default:
+		fmt.Print(common.FailedRespMsg)
+	}
+
+	return nil
+}
@@ -62,7 +62,13 @@ func GetEcsdaSignCmd() *EcsdaSignCmd {
 
 // print new account
 func (t *EcsdaSignCmd) Sign() error {
-	sign, err := auth.XassetSignECDSA(t.PrivateKey, []byte(t.Msg))
+	// 根据私钥曲线选择NIST或国密签名
+	ct, err := auth.KeyCryptoType(t.PrivateKey)
+	if err != nil {
+		fmt.Print(common.FailedRespMsg)
+		return nil
+	}
+	sign, err := auth.XassetSign(ct, t.PrivateKey, []byte(t.Msg))
 	if err != nil {
 		fmt.Print(common.FailedRespMsg)
 		return nil
@@ -1,6 +1,13 @@
 package common
 
+import "github.com/xuperchain/xasset-sdk-go/auth/keystore"
+
 const (
 	CmdLineName   = "xasset-cli"
 	FailedRespMsg = "Failed"
+
+	// 默认密钥库目录，相对用户主目录
+	DefaultKeystoreDir = ".xasset/keystore"
+	// 密钥库口令环境变量
+	EnvKeystorePass = keystore.EnvPassphrase
 )
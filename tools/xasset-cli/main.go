@@ -32,8 +32,11 @@ func NewCliCommand() (*cobra.Command, error) {
 		Example:       common.CmdLineName + " account <sub_cmd> [arguments]",
 	}
 
+	cmd.BindConfigFlags(rootCmd)
+	rootCmd.AddCommand(cmd.GetConfigCmd().GetCmd())
 	rootCmd.AddCommand(cmd.GetAccountCmd().GetCmd())
 	rootCmd.AddCommand(cmd.GetSignCmd().GetCmd())
+	rootCmd.AddCommand(cmd.GetOfflineCmd().GetCmd())
 
 	return rootCmd, nil
 }
@@ -0,0 +1,165 @@
+// xasset-signd 本地签名服务
+//
+// 从加密密钥库加载账户，通过Unix socket或本机回环HTTP提供签名接口，
+// 业务进程使用 remote.NewSigner 连接，无需加载私钥。每次签名写一行审计日志。
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	"github.com/xuperchain/xasset-sdk-go/auth/keystore"
+	"github.com/xuperchain/xasset-sdk-go/auth/remote"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	CmdLineName = "xasset-signd"
+)
+
+type SignDaemon struct {
+	Listen   string
+	Dir      string
+	Addrs    []string
+	PassFile string
+	Clients  string
+	Audit    string
+}
+
+func main() {
+	d := new(SignDaemon)
+	home, _ := os.UserHomeDir()
+	base := filepath.Join(home, ".xasset")
+
+	rootCmd := &cobra.Command{
+		Use:           CmdLineName,
+		Short:         CmdLineName + " serves signatures for keystore accounts over a local socket.",
+		Example:       CmdLineName + " -l unix:///run/xasset/signd.sock -c clients.json -p pass.txt",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return d.Run()
+		},
+	}
+	rootCmd.Flags().StringVarP(&d.Listen, "listen", "l", "unix://"+filepath.Join(base, "signd.sock"), "listen endpoint. unix:///path|http://127.0.0.1:port")
+	rootCmd.Flags().StringVarP(&d.Dir, "keystore", "d", filepath.Join(base, "keystore"), "keystore directory")
+	rootCmd.Flags().StringSliceVarP(&d.Addrs, "addr", "a", nil, "addresses to serve, default all in keystore")
+	rootCmd.Flags().StringVarP(&d.PassFile, "passfile", "p", "", "file containing the keystore passphrase")
+	rootCmd.Flags().StringVarP(&d.Clients, "clients", "c", "", "client allowlist json file. [{\"name\",\"token\",\"addrs\"}]")
+	rootCmd.Flags().StringVar(&d.Audit, "audit", filepath.Join(base, "signd-audit.log"), "audit log file")
+
+	if err := rootCmd.Execute(); err != nil {
+		log.Fatalf("%s failed.err:%v", CmdLineName, err)
+	}
+}
+
+func (t *SignDaemon) Run() error {
+	clients, err := t.loadClients()
+	if err != nil {
+		return err
+	}
+	signers, err := t.loadSigners()
+	if err != nil {
+		return err
+	}
+
+	audit, err := os.OpenFile(t.Audit, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer audit.Close()
+
+	handler, err := remote.NewServer(signers, clients, audit)
+	if err != nil {
+		return err
+	}
+	l, err := remote.Listen(t.Listen)
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Handler:      handler,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+	go func() {
+		ch := make(chan os.Signal, 1)
+		signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
+		<-ch
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(ctx)
+	}()
+
+	log.Printf("%s serving %d accounts for %d clients on %s", CmdLineName, len(signers), len(clients), t.Listen)
+	if err := srv.Serve(l); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+func (t *SignDaemon) loadClients() ([]*remote.Client, error) {
+	if t.Clients == "" {
+		return nil, fmt.Errorf("client allowlist required")
+	}
+	data, err := ioutil.ReadFile(t.Clients)
+	if err != nil {
+		return nil, err
+	}
+	var clients []*remote.Client
+	if err := json.Unmarshal(data, &clients); err != nil {
+		return nil, fmt.Errorf("client allowlist invalid.err:%v", err)
+	}
+	return clients, nil
+}
+
+// 解密密钥库账户并转为软件签名者，解密后的账户只在本进程内存中
+func (t *SignDaemon) loadSigners() ([]auth.Signer, error) {
+	ks, err := keystore.New(t.Dir, nil)
+	if err != nil {
+		return nil, err
+	}
+	addrs := t.Addrs
+	if len(addrs) == 0 {
+		entries, err := ks.List()
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			addrs = append(addrs, e.Address)
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no account in keystore %s", t.Dir)
+	}
+
+	pass, err := keystore.ReadPassphrase(t.PassFile, "passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	signers := make([]auth.Signer, 0, len(addrs))
+	for _, addr := range addrs {
+		acc, err := ks.Load(addr, pass)
+		if err != nil {
+			return nil, fmt.Errorf("load account %s failed.err:%v", addr, err)
+		}
+		s, err := auth.NewSoftSigner(acc)
+		if err != nil {
+			return nil, fmt.Errorf("load account %s failed.err:%v", addr, err)
+		}
+		signers = append(signers, s)
+	}
+	return signers, nil
+}